@@ -0,0 +1,12 @@
+{{-- Code generated by wir from action_button.wir. DO NOT EDIT. --}}
+{{--
+  Context:
+    label: string
+    primary: bool = false
+--}}
+@props(['label', 'primary' => false])
+@if($primary)
+  <button class="p-4 bg-black text-white">{{ $label }}</button>
+@else
+  <button class="p-4">{{ $label }}</button>
+@endif
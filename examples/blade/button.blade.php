@@ -0,0 +1,2 @@
+{{-- Code generated by wir from button.wir. DO NOT EDIT. --}}
+<button class="p-4 text-sm bg-black rounded-lg"></button>
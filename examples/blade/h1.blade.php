@@ -0,0 +1,2 @@
+{{-- Code generated by wir from h1.wir. DO NOT EDIT. --}}
+<h1 class="text-3xl font-bold">Hello, World!</h1>
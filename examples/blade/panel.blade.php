@@ -0,0 +1,15 @@
+{{-- Code generated by wir from panel.wir. DO NOT EDIT. --}}
+{{--
+  Context:
+    heading: string
+--}}
+@props(['heading', 'footer' => null])
+<div class="rounded border p-4">
+  <h1>{{ $heading }}</h1>
+  {{ $slot }}
+  @if(isset($footer))
+    {{ $footer }}
+  @else
+    <p>Nothing to do here</p>
+  @endif
+</div>
@@ -0,0 +1,17 @@
+{{-- Code generated by wir from role_badge.wir. DO NOT EDIT. --}}
+{{--
+  Context:
+    isAdmin: bool
+    isEditor: bool
+--}}
+@props(['isAdmin', 'isEditor'])
+<div>
+  @if($isAdmin)
+    <h1>Admin</h1>
+  @elseif($isEditor)
+    <p>Editor</p>
+  @else
+    <p>Guest</p>
+    <button>Sign in</button>
+  @endif
+</div>
@@ -0,0 +1,13 @@
+{{-- Code generated by wir from settings_panel.wir. DO NOT EDIT. --}}
+{{--
+  Context:
+    title: string
+    canSave: bool
+--}}
+@props(['title', 'canSave'])
+<x-panel :heading="$title">
+  <p>Changes are saved to your profile</p>
+  <x-slot:footer>
+    <x-action_button label="Save" :primary="$canSave" />
+  </x-slot>
+</x-panel>
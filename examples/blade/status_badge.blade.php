@@ -0,0 +1,22 @@
+{{-- Code generated by wir from status_badge.wir. DO NOT EDIT. --}}
+{{--
+  Context:
+    status: Status
+    pausedBy: string
+
+  Status: 'active' | 'on-hold'
+--}}
+@props(['status', 'pausedBy'])
+<div>
+  @switch($status)
+    @case('active')
+      <h1>Active</h1>
+      @break
+    @case('on-hold')
+      <h1>On hold</h1>
+      <p>Paused by {{ $pausedBy }}</p>
+      @break
+    @default
+      <p>Unknown status</p>
+  @endswitch
+</div>
@@ -0,0 +1,26 @@
+{{-- Code generated by wir from team_card.wir. DO NOT EDIT. --}}
+{{--
+  Context:
+    title: string
+    users: []User
+    compact: bool = false
+    maxShown: int = 3
+    footer: string = 'Thanks for visiting'
+
+  User:
+    name: string
+--}}
+@props(['title', 'users', 'compact' => false, 'maxShown' => 3, 'footer' => 'Thanks for visiting'])
+<div>
+  <h1>{{ $title }}</h1>
+  @if($compact)
+    <p>Showing {{ $maxShown }} members</p>
+  @else
+    <ul>
+      @foreach($users as $user)
+        <li>{{ $user->name }}</li>
+      @endforeach
+    </ul>
+  @endif
+  <p>{{ $footer }}</p>
+</div>
@@ -0,0 +1,13 @@
+{{-- Code generated by wir from toolbar.wir. DO NOT EDIT. --}}
+{{--
+  Context:
+    title: string
+    action: string
+    canSave: bool
+--}}
+@props(['title', 'action', 'canSave'])
+<div class="flex gap-2">
+  <h1>{{ $title }}</h1>
+  <x-action_button :label="$action" :primary="$canSave" />
+  <x-action_button label="Cancel" />
+</div>
@@ -0,0 +1,18 @@
+{{-- Code generated by wir from user_list.wir. DO NOT EDIT. --}}
+{{--
+  Context:
+    someClass: string
+    listName: string
+    users: []User
+
+  User:
+    name: string
+--}}
+@props(['someClass', 'listName', 'users'])
+<ul id="my-list" class="bg-black {{ $someClass }}">
+  <li></li>
+  Name: {{ $listName }}
+  @foreach($users as $user)
+    <li>name: {{ $user->name }}</li>
+  @endforeach
+</ul>
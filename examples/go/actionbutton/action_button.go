@@ -0,0 +1,45 @@
+// Code generated by wir from action_button.wir. DO NOT EDIT.
+
+package actionbutton
+
+import (
+	"html"
+	"io"
+)
+
+type Props struct {
+	Label   string
+	Primary bool
+}
+
+// DefaultProps returns Props holding the defaults declared by @props.
+func DefaultProps() Props {
+	return Props{
+		Primary: false,
+	}
+}
+
+func Render(w io.Writer, p Props) error {
+	if p.Primary {
+		if _, err := io.WriteString(w, "<button class=\"p-4 bg-black text-white\">"); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, html.EscapeString(p.Label)); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, "</button>"); err != nil {
+			return err
+		}
+	} else {
+		if _, err := io.WriteString(w, "<button class=\"p-4\">"); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, html.EscapeString(p.Label)); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, "</button>"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
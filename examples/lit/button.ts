@@ -0,0 +1,13 @@
+// Code generated by wir from button.wir. DO NOT EDIT.
+
+import { LitElement, html } from "lit";
+import { customElement } from "lit/decorators.js";
+
+@customElement("wir-button")
+export class Button extends LitElement {
+  render() {
+    return html`
+      <button class="p-4 text-sm bg-black rounded-lg"></button>
+    `;
+  }
+}
@@ -0,0 +1,18 @@
+// Code generated by wir from button.wir. DO NOT EDIT.
+
+export class Button extends HTMLElement {
+  constructor() {
+    super();
+    this.attachShadow({ mode: "open" });
+  }
+
+  connectedCallback() {
+    this.render();
+  }
+
+  render() {
+    this.shadowRoot.innerHTML = `<button class="p-4 text-sm bg-black rounded-lg"></button>`;
+  }
+}
+
+customElements.define("wir-button", Button);
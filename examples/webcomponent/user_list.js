@@ -0,0 +1,93 @@
+// Code generated by wir from user_list.wir. DO NOT EDIT.
+
+const escapeHtml = (value) =>
+  String(value).replace(/[&<>"']/g, (ch) => ({ "&": "&amp;", "<": "&lt;", ">": "&gt;", '"': "&quot;", "'": "&#39;" })[ch]);
+
+export class UserList extends HTMLElement {
+  static observedAttributes = ["some-class", "list-name", "users"];
+
+  #someClass = "";
+  #listName = "";
+  #users = [];
+
+  constructor() {
+    super();
+    this.attachShadow({ mode: "open" });
+  }
+
+  connectedCallback() {
+    this.render();
+  }
+
+  attributeChangedCallback(name, oldValue, newValue) {
+    switch (name) {
+      case "some-class":
+        this.someClass = newValue ?? "";
+        break;
+      case "list-name":
+        this.listName = newValue ?? "";
+        break;
+      case "users":
+        this.users = JSON.parse(newValue ?? "[]");
+        break;
+    }
+  }
+
+  get someClass() {
+    return this.#someClass;
+  }
+
+  set someClass(value) {
+    this.#someClass = value;
+    if (!this.isConnected) {
+      return;
+    }
+    this.#update0();
+  }
+
+  get listName() {
+    return this.#listName;
+  }
+
+  set listName(value) {
+    this.#listName = value;
+    if (!this.isConnected) {
+      return;
+    }
+    this.#update1();
+  }
+
+  get users() {
+    return this.#users;
+  }
+
+  set users(value) {
+    this.#users = value;
+    if (!this.isConnected) {
+      return;
+    }
+    this.#update1();
+  }
+
+  render() {
+    this.shadowRoot.innerHTML = `<ul data-wir="0" id="my-list" class="bg-black ${escapeHtml(this.someClass)}"><li></li>Name: ${escapeHtml(this.listName)}${this.users.map((user) => `<li>name: ${escapeHtml(user.name)}</li>`).join("")}</ul>`;
+  }
+
+  #update0() {
+    const el = this.shadowRoot.querySelector('[data-wir="0"]');
+    if (!el) {
+      return;
+    }
+    el.setAttribute("class", `bg-black ${this.someClass}`);
+  }
+
+  #update1() {
+    const el = this.shadowRoot.querySelector('[data-wir="0"]');
+    if (!el) {
+      return;
+    }
+    el.innerHTML = `<li></li>Name: ${escapeHtml(this.listName)}${this.users.map((user) => `<li>name: ${escapeHtml(user.name)}</li>`).join("")}`;
+  }
+}
+
+customElements.define("user-list", UserList);
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+
+	"github.com/phillip-england/wir/internal/mood"
+	"github.com/phillip-england/wir/internal/soak"
+	"github.com/phillip-england/wir/internal/wherr"
+	"github.com/phillip-england/wir/internal/wirdiag"
+	"github.com/phillip-england/wir/internal/wirgen"
+)
+
+type CmdBuild struct {
+	argInPath       string
+	argOutPath      string
+	inPathAbs       string
+	outPathAbs      string
+	shouldOverwrite bool
+	isTargetingDir  bool
+	target          wirgen.Target
+}
+
+func NewCmdBuild(cli *mood.Cli) (mood.Cmd, error) {
+	argInPath, err := cli.ArgGetByPositionForce(2, "missing <INPUT_FILE> for wir build")
+	if err != nil {
+		return CmdBuild{}, wherr.Consume(wherr.Here(), err, "")
+	}
+	argOutPath, err := cli.ArgGetByPositionForce(3, "missing <OUTPUT_FILE> for wir build")
+	if err != nil {
+		return CmdBuild{}, wherr.Consume(wherr.Here(), err, "")
+	}
+	inPathAbs := path.Join(cli.Cwd, argInPath)
+	if !mood.FileExists(inPathAbs) {
+		return CmdBuild{}, wherr.Err(wherr.Here(), "<INPUT_FILE> does not exist in wir build")
+	}
+	isTargetingDir := false
+	if mood.IsDir(inPathAbs) {
+		isTargetingDir = true
+	}
+	if isTargetingDir {
+		if !mood.IsDir(argOutPath) {
+			return CmdBuild{}, wherr.Err(wherr.Here(), "<OUTPUT_FILE> must be a directory if <INPUT_FILE> is a directory")
+		}
+	} else {
+		if !mood.IsFile(argOutPath) {
+			return CmdBuild{}, wherr.Err(wherr.Here(), "<OUTPUT_FILE> must be a file path if <INPUT_FILE> is a file")
+		}
+	}
+	// each component of a directory declares the same Props and Render, so
+	// they can't share the one package --package names
+	if isTargetingDir && cli.FlagExists("--package") {
+		return CmdBuild{}, wherr.Err(wherr.Here(), "--package only applies to building a single file, a directory build gives every component its own package")
+	}
+	opts := wirgen.TargetOptions{
+		Package: cli.FlagValueOrDefault("--package", ""),
+		Module:  cli.FlagValueOrDefault("--module", ""),
+	}
+	if propsPath, exists := cli.FlagValue("--props"); exists {
+		props, err := loadProps(path.Join(cli.Cwd, propsPath))
+		if err != nil {
+			return CmdBuild{}, wherr.Consume(wherr.Here(), err, "")
+		}
+		opts.Props = props
+	}
+	target, err := wirgen.TargetNew(cli.FlagValueOrDefault("--target", "html"), opts)
+	if err != nil {
+		return CmdBuild{}, wherr.Consume(wherr.Here(), err, "")
+	}
+	return CmdBuild{
+		argInPath:       argInPath,
+		argOutPath:      argOutPath,
+		inPathAbs:       inPathAbs,
+		outPathAbs:      path.Join(cli.Cwd, argOutPath),
+		shouldOverwrite: cli.FlagExists("-o"),
+		isTargetingDir:  isTargetingDir,
+		target:          target,
+	}, nil
+}
+
+func (cmd CmdBuild) Execute(cli *mood.Cli) error {
+	if cmd.isTargetingDir {
+		err := buildDir(cmd)
+		if err != nil {
+			return wherr.Consume(wherr.Here(), err, "")
+		}
+	} else {
+		err := buildFile(cmd)
+		if err != nil {
+			return wherr.Consume(wherr.Here(), err, "")
+		}
+	}
+	return nil
+}
+
+func loadProps(absPath string) (map[string]any, error) {
+	fBytes, err := os.ReadFile(absPath)
+	if err != nil {
+		return nil, wherr.Consume(wherr.Here(), err, "")
+	}
+	props := map[string]any{}
+	err = json.Unmarshal(fBytes, &props)
+	if err != nil {
+		return nil, wherr.Err(wherr.Here(), "props file %s is not a JSON object: %s", absPath, err.Error())
+	}
+	return props, nil
+}
+
+// buildSource compiles a single component of project with target, returning
+// the output along with the directory it belongs in relative to the output
+// dir, which mirrors the directory of the component in the project. Problems
+// in the template come back as a wirdiag.List.
+func buildSource(target wirgen.Target, project *wirgen.Project, relPath string) (string, string, error) {
+	c, err := project.Component(relPath)
+	if err != nil {
+		return "", "", err
+	}
+	out, err := target.Generate(c)
+	if err != nil {
+		return "", "", err
+	}
+	dir := path.Dir(c.RelPath)
+	if nested, ok := target.(wirgen.TargetNested); ok {
+		dir = path.Join(dir, nested.Dir(c))
+	}
+	return out, dir, nil
+}
+
+func buildFile(cmd CmdBuild) error {
+	if cmd.shouldOverwrite {
+		os.RemoveAll(cmd.outPathAbs)
+	}
+	if mood.FileExists(cmd.outPathAbs) {
+		return wherr.Err(wherr.Here(), "file already exists at %s", cmd.outPathAbs)
+	}
+	// the rest of the directory is loaded for the files the component imports
+	vfs, err := soak.LoadVfsAbsolute(true, path.Dir(cmd.inPathAbs))
+	if err != nil {
+		return wherr.Consume(wherr.Here(), err, "")
+	}
+	out, _, err := buildSource(cmd.target, wirgen.ProjectNewFromVfs(vfs), cmd.argInPath)
+	if err != nil {
+		return wherr.Consume(wherr.Here(), err, "")
+	}
+	err = os.WriteFile(cmd.outPathAbs, []byte(out), 0644)
+	if err != nil {
+		return wherr.Consume(wherr.Here(), err, "")
+	}
+	return nil
+}
+
+func buildDir(cmd CmdBuild) error {
+	if cmd.shouldOverwrite {
+		os.RemoveAll(cmd.outPathAbs)
+	}
+	if mood.FileExists(cmd.outPathAbs) {
+		return wherr.Err(wherr.Here(), "dir already exists at %s", cmd.outPathAbs)
+	}
+	vfs, err := soak.LoadVfsAbsolute(true, cmd.inPathAbs)
+	if err != nil {
+		return wherr.Consume(wherr.Here(), err, "")
+	}
+	project := wirgen.ProjectNewFromVfs(vfs)
+	// a target nesting components claims a directory per component, since
+	// two in one package would declare the same names
+	_, isNested := cmd.target.(wirgen.TargetNested)
+	claimed := map[string]string{}
+	var potErr error
+	var diags wirdiag.List
+	vfs.IterAssets(func(a *soak.VirtualAsset) bool {
+		if a.Ext != ".wir" {
+			return true
+		}
+		out, dir, err := buildSource(cmd.target, project, a.RelPath)
+		if err != nil {
+			fileDiags, ok := err.(wirdiag.List)
+			if !ok {
+				potErr = wherr.Consume(wherr.Here(), err, "")
+				return false
+			}
+			diags = append(diags, fileDiags...)
+			return true
+		}
+		outPath := path.Join(cmd.outPathAbs, dir, a.FileNameNoExt+cmd.target.Ext())
+		claim := outPath
+		if isNested {
+			claim = path.Dir(outPath)
+		}
+		if other, exists := claimed[claim]; exists {
+			potErr = wherr.Err(wherr.Here(), "%s and %s would both be written to %s", other, a.RelPath, claim)
+			return false
+		}
+		claimed[claim] = a.RelPath
+		err = os.MkdirAll(path.Dir(outPath), 0755)
+		if err != nil {
+			potErr = wherr.Consume(wherr.Here(), err, "")
+			return false
+		}
+		err = os.WriteFile(outPath, []byte(out), 0644)
+		if err != nil {
+			potErr = wherr.Consume(wherr.Here(), err, "")
+			return false
+		}
+		return true
+	})
+	if potErr != nil {
+		return potErr
+	}
+	return diags.Err()
+}
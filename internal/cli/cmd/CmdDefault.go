@@ -16,6 +16,15 @@ func (cmd CmdDefault) Execute(cli *mood.Cli) error {
 	fmt.Println(`[webIR]: a language for expressing reactive web UI's across multiple platforms
 [tokenize example/usage]:
   -wir tokenize <INPUT_FILE> <OUTPUT_FILE>
-  -wir tokenize ./input.wir ./output.txt`)
+  -wir tokenize ./input.wir ./output.txt
+[parse example/usage]:
+  -wir parse <INPUT_FILE> <OUTPUT_FILE>
+  -wir parse ./input.wir ./output.json
+[build example/usage]:
+  -wir build <INPUT_FILE> <OUTPUT_FILE> --target <TARGET> --props <PROPS_FILE>
+  -wir build ./input.wir ./output.html --target html --props ./props.json
+  -wir build ./user_list.wir ./views/user_list.go --target go --package views
+  -wir build ./components ./views --target go --module example.com/app/views
+  -wir build ./team_card.wir ./views/team_card.templ --target templ --package views`)
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"os"
+	"path"
+
+	"github.com/phillip-england/wir/internal/mood"
+	"github.com/phillip-england/wir/internal/soak"
+	"github.com/phillip-england/wir/internal/wherr"
+	"github.com/phillip-england/wir/internal/wirdiag"
+	"github.com/phillip-england/wir/internal/wirparser"
+	"github.com/phillip-england/wir/internal/wirtokenizer"
+)
+
+type CmdParse struct {
+	argInPath       string
+	argOutPath      string
+	inPathAbs       string
+	outPathAbs      string
+	shouldOverwrite bool
+	isTargetingDir  bool
+}
+
+func NewCmdParse(cli *mood.Cli) (mood.Cmd, error) {
+	argInPath, err := cli.ArgGetByPositionForce(2, "missing <INPUT_FILE> for wir parse")
+	if err != nil {
+		return CmdParse{}, wherr.Consume(wherr.Here(), err, "")
+	}
+	argOutPath, err := cli.ArgGetByPositionForce(3, "missing <OUTPUT_FILE> for wir parse")
+	if err != nil {
+		return CmdParse{}, wherr.Consume(wherr.Here(), err, "")
+	}
+	inPathAbs := path.Join(cli.Cwd, argInPath)
+	if !mood.FileExists(inPathAbs) {
+		return CmdParse{}, wherr.Err(wherr.Here(), "<INPUT_FILE> does not exist in wir parse")
+	}
+	isTargetingDir := false
+	if mood.IsDir(inPathAbs) {
+		isTargetingDir = true
+	}
+	if isTargetingDir {
+		if !mood.IsDir(argOutPath) {
+			return CmdParse{}, wherr.Err(wherr.Here(), "<OUTPUT_FILE> must be a directory if <INPUT_FILE> is a directory")
+		}
+	} else {
+		if !mood.IsFile(argOutPath) {
+			return CmdParse{}, wherr.Err(wherr.Here(), "<OUTPUT_FILE> must be a file path if <INPUT_FILE> is a file")
+		}
+	}
+	return CmdParse{
+		argInPath:       argInPath,
+		argOutPath:      argOutPath,
+		inPathAbs:       inPathAbs,
+		outPathAbs:      path.Join(cli.Cwd, argOutPath),
+		shouldOverwrite: cli.FlagExists("-o"),
+		isTargetingDir:  isTargetingDir,
+	}, nil
+}
+
+func (cmd CmdParse) Execute(cli *mood.Cli) error {
+	if cmd.isTargetingDir {
+		err := parseDir(cmd)
+		if err != nil {
+			return wherr.Consume(wherr.Here(), err, "")
+		}
+	} else {
+		err := parseFile(cmd)
+		if err != nil {
+			return wherr.Consume(wherr.Here(), err, "")
+		}
+	}
+	return nil
+}
+
+// parseToJson tokenizes and parses s, returning its AST as JSON. Syntax
+// errors come back as a wirdiag.List.
+func parseToJson(relPath string, s string) (string, error) {
+	tk, err := wirtokenizer.TokenizerNewFromSource(relPath, s)
+	if err != nil {
+		return "", err
+	}
+	p, err := wirparser.ParserNewFromTokenizer(tk)
+	if err != nil {
+		return "", err
+	}
+	return p.Ast().Json()
+}
+
+func parseFile(cmd CmdParse) error {
+	if cmd.shouldOverwrite {
+		os.RemoveAll(cmd.outPathAbs)
+	}
+	if mood.FileExists(cmd.outPathAbs) {
+		return wherr.Err(wherr.Here(), "file already exists at %s", cmd.outPathAbs)
+	}
+	fBytes, err := os.ReadFile(cmd.inPathAbs)
+	if err != nil {
+		return wherr.Consume(wherr.Here(), err, "")
+	}
+	out, err := parseToJson(cmd.argInPath, string(fBytes))
+	if err != nil {
+		return wherr.Consume(wherr.Here(), err, "")
+	}
+	err = os.WriteFile(cmd.outPathAbs, []byte(out), 0644)
+	if err != nil {
+		return wherr.Consume(wherr.Here(), err, "")
+	}
+	return nil
+}
+
+func parseDir(cmd CmdParse) error {
+	if cmd.shouldOverwrite {
+		os.RemoveAll(cmd.outPathAbs)
+	}
+	if mood.FileExists(cmd.outPathAbs) {
+		return wherr.Err(wherr.Here(), "dir already exists at %s", cmd.outPathAbs)
+	}
+	vfs, err := soak.LoadVfsAbsolute(true, cmd.inPathAbs)
+	if err != nil {
+		return wherr.Consume(wherr.Here(), err, "")
+	}
+	var potErr error
+	var diags wirdiag.List
+	vfs.IterAssets(func(a *soak.VirtualAsset) bool {
+		outPath := path.Join(cmd.outPathAbs, a.FileNameNoExt+".json")
+		out, err := parseToJson(a.RelPath, a.Text)
+		if err != nil {
+			fileDiags, ok := err.(wirdiag.List)
+			if !ok {
+				potErr = wherr.Consume(wherr.Here(), err, "")
+				return false
+			}
+			diags = append(diags, fileDiags...)
+			return true
+		}
+		err = os.MkdirAll(path.Dir(outPath), 0755)
+		if err != nil {
+			potErr = wherr.Consume(wherr.Here(), err, "")
+			return false
+		}
+		err = os.WriteFile(outPath, []byte(out), 0644)
+		if err != nil {
+			potErr = wherr.Consume(wherr.Here(), err, "")
+			return false
+		}
+		return true
+	})
+	if potErr != nil {
+		return potErr
+	}
+	return diags.Err()
+}
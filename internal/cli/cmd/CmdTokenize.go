@@ -8,6 +8,7 @@ import (
 	"github.com/phillip-england/wir/internal/mood"
 	"github.com/phillip-england/wir/internal/soak"
 	"github.com/phillip-england/wir/internal/wherr"
+	"github.com/phillip-england/wir/internal/wirdiag"
 	"github.com/phillip-england/wir/internal/wirtokenizer"
 )
 
@@ -83,7 +84,7 @@ func tokenizeFile(cmd CmdTokenize) error {
 		return wherr.Consume(wherr.Here(), err, "")
 	}
 	fStr := string(fBytes)
-	tk, err := wirtokenizer.TokenizerNewFromString(fStr)
+	tk, err := wirtokenizer.TokenizerNewFromSource(cmd.argInPath, fStr)
 	if err != nil {
 		return wherr.Consume(wherr.Here(), err, "")
 	}
@@ -106,11 +107,18 @@ func tokenizeDir(cmd CmdTokenize) error {
 		return wherr.Consume(wherr.Here(), err, "")
 	}
 	var potErr error
+	var diags wirdiag.List
 	vfs.IterAssets(func(a *soak.VirtualAsset) bool {
 		outDirPath := path.Join(cmd.outPathAbs, a.FileNameNoExt+".tok")
-		tk, err := wirtokenizer.TokenizerNewFromString(a.Text)
+		tk, err := wirtokenizer.TokenizerNewFromSource(a.RelPath, a.Text)
 		if err != nil {
-			potErr = wherr.Consume(wherr.Here(), err, "")
+			fileDiags, ok := err.(wirdiag.List)
+			if !ok {
+				potErr = wherr.Consume(wherr.Here(), err, "")
+				return false
+			}
+			diags = append(diags, fileDiags...)
+			return true
 		}
 		err = os.MkdirAll(path.Dir(outDirPath), 0755)
 		if err != nil {
@@ -126,5 +134,5 @@ func tokenizeDir(cmd CmdTokenize) error {
 	if potErr != nil {
 		return potErr
 	}
-	return nil
+	return diags.Err()
 }
\ No newline at end of file
@@ -1,10 +1,13 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"os"
 
 	"github.com/phillip-england/wir/internal/cli/cmd"
 	"github.com/phillip-england/wir/internal/mood"
+	"github.com/phillip-england/wir/internal/wirdiag"
 )
 
 func main() {
@@ -13,15 +16,27 @@ func main() {
 	cli, err := mood.New(cmd.NewCmdDefault)
 	if err != nil {
 		fmt.Println(err.Error())
-		return
+		os.Exit(1)
 	}
 	
 	cli.At("tokenize", cmd.NewCmdTokenize)
+	cli.At("parse", cmd.NewCmdParse)
+	cli.At("build", cmd.NewCmdBuild)
 
 	err = cli.Run()
 	if err != nil {
+		var diags wirdiag.List
+		if errors.As(err, &diags) {
+			fmt.Println(diags.Error())
+			os.Exit(1)
+		}
+		var diag *wirdiag.Diagnostic
+		if errors.As(err, &diag) {
+			fmt.Println(diag.Error())
+			os.Exit(1)
+		}
 		fmt.Println(err.Error())
-		return
+		os.Exit(1)
 	}
 }
 
@@ -2,6 +2,7 @@ package mood
 
 import (
 	"os"
+	"strings"
 
 	"github.com/phillip-england/wir/internal/wherr"
 )
@@ -107,6 +108,28 @@ func (cli *Cli) FlagExists(flag string) bool {
 	return exists
 }
 
+// FlagValue returns the value given to a flag, written either as
+// "--flag value" or "--flag=value".
+func (cli *Cli) FlagValue(flag string) (string, bool) {
+	for key, arg := range cli.Flags {
+		if strings.HasPrefix(key, flag+"=") {
+			return strings.TrimPrefix(arg.Value, flag+"="), true
+		}
+	}
+	f, exists := cli.Flags[flag]
+	if !exists {
+		return "", false
+	}
+	return cli.ArgGetByPosition(f.Position + 1)
+}
+
+func (cli *Cli) FlagValueOrDefault(flag string, defaultValue string) string {
+	if val, exists := cli.FlagValue(flag); exists {
+		return val
+	}
+	return defaultValue
+}
+
 func (cli *Cli) ArgExists(arg string) bool {
 	_, exists := cli.Args[arg]
 	return exists
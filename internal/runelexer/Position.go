@@ -0,0 +1,42 @@
+package runelexer
+
+import "fmt"
+
+// Position is a location in source text. Line and Column are 1-based and
+// counted in runes, Offset is the 0-based byte offset.
+type Position struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+	Offset int `json:"offset"`
+}
+
+func PositionStart() Position {
+	return Position{
+		Line:   1,
+		Column: 1,
+		Offset: 0,
+	}
+}
+
+func (p Position) Str() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Advance returns the position reached after reading s starting at p.
+func (p Position) Advance(s string) Position {
+	for _, r := range s {
+		p = p.advanceRune(r)
+	}
+	return p
+}
+
+func (p Position) advanceRune(r rune) Position {
+	p.Offset += len(string(r))
+	if r == '\n' {
+		p.Line++
+		p.Column = 1
+		return p
+	}
+	p.Column++
+	return p
+}
@@ -12,9 +12,18 @@ type RuneLexer[T any] struct {
 	tokens      []T
 	state       int
 	store       []rune
+	storeStart  int
+	origin      Position
+	positions   []Position
 }
 
 func NewRuneLexer[T any](s string) *RuneLexer[T] {
+	return NewRuneLexerAt[T](s, PositionStart())
+}
+
+// NewRuneLexerAt creates a lexer for s where s begins at origin within some
+// larger source, so positions reported by the lexer point back into it.
+func NewRuneLexerAt[T any](s string, origin Position) *RuneLexer[T] {
 	runes := []rune(s)
 	return &RuneLexer[T]{
 		position:    0,
@@ -23,9 +32,35 @@ func NewRuneLexer[T any](s string) *RuneLexer[T] {
 		markedPos:   0,
 		tokens:      []T{},
 		state:       0,
+		origin:      origin,
 	}
 }
 
+// PositionAt returns the source position of the rune at index pos. An index
+// of Len() gives the position just past the final rune.
+func (l *RuneLexer[T]) PositionAt(pos int) Position {
+	if l.positions == nil {
+		l.positions = make([]Position, 0, len(l.runes)+1)
+		p := l.origin
+		for _, r := range l.runes {
+			l.positions = append(l.positions, p)
+			p = p.advanceRune(r)
+		}
+		l.positions = append(l.positions, p)
+	}
+	if pos < 0 {
+		pos = 0
+	}
+	if pos >= len(l.positions) {
+		pos = len(l.positions) - 1
+	}
+	return l.positions[pos]
+}
+
+func (l *RuneLexer[T]) Position() Position {
+	return l.PositionAt(l.position)
+}
+
 func (l *RuneLexer[T]) State() int {
 	return l.state
 }
@@ -337,10 +372,18 @@ func contains(arr []string, target string) bool {
 
 func (l *RuneLexer[T]) Store() {
 	if l.position >= 0 && l.position < len(l.runes) {
+		if len(l.store) == 0 {
+			l.storeStart = l.position
+		}
 		l.store = append(l.store, l.runes[l.position])
 	}
 }
 
+// StoreStart returns the index of the first rune held in the store.
+func (l *RuneLexer[T]) StoreStart() int {
+	return l.storeStart
+}
+
 func (l *RuneLexer[T]) StoreStr() string {
 	return string(l.store)
 }
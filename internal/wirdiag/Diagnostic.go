@@ -0,0 +1,80 @@
+package wirdiag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/phillip-england/wir/internal/runelexer"
+)
+
+// Diagnostic is a problem found in a .wir source file. Unlike a wherr.Wherr,
+// which records where in the Go source an error was raised, a Diagnostic
+// points at the offending text in the user's template.
+type Diagnostic struct {
+	File    string
+	Line    int
+	Column  int
+	Offset  int
+	Message string
+	Excerpt string
+}
+
+func DiagnosticNew(pos runelexer.Position, format string, args ...any) *Diagnostic {
+	return &Diagnostic{
+		Line:    pos.Line,
+		Column:  pos.Column,
+		Offset:  pos.Offset,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// Attach records the file a diagnostic belongs to and renders the excerpt of
+// src the diagnostic points at.
+func (d *Diagnostic) Attach(file string, src string) *Diagnostic {
+	d.File = file
+	d.Excerpt = excerpt(src, d.Line, d.Column)
+	return d
+}
+
+func (d *Diagnostic) Location() string {
+	if d.File == "" {
+		return fmt.Sprintf("%d:%d", d.Line, d.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", d.File, d.Line, d.Column)
+}
+
+func (d *Diagnostic) Error() string {
+	s := fmt.Sprintf("%s: %s", d.Location(), d.Message)
+	if d.Excerpt != "" {
+		s += "\n" + d.Excerpt
+	}
+	return s
+}
+
+// excerpt renders the source line at line with a caret beneath column:
+//
+//	3 |   'Name: ${listName: string}
+//	  |   ^
+func excerpt(src string, line int, column int) string {
+	lines := strings.Split(src, "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	text := strings.TrimRight(lines[line-1], "\r")
+	runes := []rune(text)
+	if column-1 > len(runes) {
+		column = len(runes) + 1
+	}
+	pad := ""
+	for _, r := range runes[:column-1] {
+		if r == '\t' {
+			pad += "\t"
+			continue
+		}
+		pad += " "
+	}
+	gutter := strconv.Itoa(line)
+	blank := strings.Repeat(" ", len(gutter))
+	return fmt.Sprintf("%s | %s\n%s | %s^", gutter, text, blank, pad)
+}
@@ -0,0 +1,30 @@
+package wirdiag
+
+import "strings"
+
+// List collects every diagnostic found in a run so they can be reported
+// together rather than stopping at the first.
+type List []*Diagnostic
+
+func (l List) Error() string {
+	var parts []string
+	for _, d := range l {
+		parts = append(parts, d.Error())
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func (l List) Attach(file string, src string) List {
+	for _, d := range l {
+		d.Attach(file, src)
+	}
+	return l
+}
+
+// Err returns l as an error, or nil when l is empty.
+func (l List) Err() error {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
@@ -0,0 +1,856 @@
+package wirgen
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/phillip-england/wir/internal/wirdiag"
+	"github.com/phillip-england/wir/internal/wirparser"
+	"github.com/phillip-england/wir/internal/wirtokenizer"
+)
+
+// Field is a typed name a component reads, either one of its props or a
+// field on one of its user types. Default holds the default @props declares
+// for a prop, decoded the way encoding/json decodes a props file, and is nil
+// when there is none.
+type Field struct {
+	Name    string
+	Type    string
+	Span    wirtokenizer.Span
+	Default any
+}
+
+// TypeDef is a user type such as User, built up from the fields accessed on
+// @for bindings of that type, so user.name: string gives User a name field.
+// A type switched on by @switch is instead an enum of string Values, one per
+// @case literal.
+type TypeDef struct {
+	Name   string
+	Fields []Field
+	Values []string
+}
+
+func (t *TypeDef) IsEnum() bool {
+	return len(t.Values) > 0
+}
+
+// Component is a parsed .wir file along with the props and types inferred
+// from its ${name: type} interpolations, @for directives, @if conditions and
+// @switch values. A file declaring @props has exactly the props it declares,
+// in that order, and its interpolations may leave out their types. RelPath
+// is its path relative to the root of its project, where Path is the one
+// diagnostics point at. Imports
+// holds the components it uses, which a Project resolves, and Slots the
+// places callers can pass content into. Every target generates code from a
+// Component.
+type Component struct {
+	Name    string
+	Path    string
+	RelPath string
+	Source  string
+	Ast     *wirparser.Ast
+	Props   []Field
+	Types   []*TypeDef
+	Imports []*Component
+	Slots   []Slot
+}
+
+// Slot is a @slot of a component. The default slot, which takes whatever a
+// caller nests in the component outside of a @fill, has an empty Name.
+type Slot struct {
+	Name string
+	Span wirtokenizer.Span
+}
+
+// Fill is the content a caller passes to one of the slots of a component.
+type Fill struct {
+	Slot     Slot
+	Children []*wirparser.AstNode
+}
+
+// Arg is a prop passed to an imported component. Parts holds the attribute
+// value it was given, unless the value is static, in which case Value holds
+// it the way Field.Default does.
+type Arg struct {
+	Prop  Field
+	Parts []*wirparser.AstNode
+	Value any
+}
+
+// Scope maps the @for bindings visible at a point in the tree to their types.
+type Scope map[string]string
+
+func (s Scope) With(name string, t string) Scope {
+	out := Scope{}
+	for k, v := range s {
+		out[k] = v
+	}
+	out[name] = t
+	return out
+}
+
+func (s Scope) Has(name string) bool {
+	_, exists := s[name]
+	return exists
+}
+
+// ComponentNewFromSource analyzes a single .wir file. A file using @import
+// needs the files it imports, so it has to be loaded through a Project.
+func ComponentNewFromSource(path string, src string) (*Component, error) {
+	c, err := componentParse(path, src)
+	if err != nil {
+		return nil, err
+	}
+	return c, c.check()
+}
+
+func componentParse(path string, src string) (*Component, error) {
+	tk, err := wirtokenizer.TokenizerNewFromSource(path, src)
+	if err != nil {
+		return nil, err
+	}
+	p, err := wirparser.ParserNewFromTokenizer(tk)
+	if err != nil {
+		return nil, err
+	}
+	return &Component{
+		Name:    ComponentName(path),
+		Path:    path,
+		RelPath: path,
+		Source:  src,
+		Ast:     p.Ast(),
+	}, nil
+}
+
+// check analyzes c once its imports are resolved.
+func (c *Component) check() error {
+	diags := c.analyze()
+	if len(diags) > 0 {
+		return diags.Attach(c.Path, c.Source)
+	}
+	return nil
+}
+
+// Diag creates a diagnostic pointing at span within the component's source.
+func (c *Component) Diag(span wirtokenizer.Span, format string, args ...any) *wirdiag.Diagnostic {
+	return wirdiag.DiagnosticNew(span.Start, format, args...).Attach(c.Path, c.Source)
+}
+
+func (c *Component) Prop(name string) (Field, bool) {
+	for _, p := range c.Props {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return Field{}, false
+}
+
+func (c *Component) Type(name string) (*TypeDef, bool) {
+	for _, t := range c.Types {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// Import finds the imported component named name.
+func (c *Component) Import(name string) (*Component, bool) {
+	for _, imp := range c.Imports {
+		if imp.Name == name {
+			return imp, true
+		}
+	}
+	return nil, false
+}
+
+// Args lists the props the COMPONENT node n passes, in the order the
+// component it uses declares them. Props left out are included with their
+// default when withDefaults is set, for targets that have to pass every prop.
+func (c *Component) Args(n *wirparser.AstNode, withDefaults bool) []Arg {
+	u, _ := c.Import(n.TagName)
+	var args []Arg
+	for _, prop := range u.Props {
+		attr, passed := findAttr(n, prop.Name)
+		switch {
+		case passed && attr.IsBool():
+			{
+				args = append(args, Arg{
+					Prop:  prop,
+					Value: true,
+				})
+			}
+		case passed && !hasInterpolation(attr.Parts):
+			{
+				val, _ := staticValue(prop.Type, joinParts(attr.Parts, func(s string) string { return s }, nil))
+				args = append(args, Arg{
+					Prop:  prop,
+					Value: val,
+				})
+			}
+		case passed:
+			{
+				args = append(args, Arg{
+					Prop:  prop,
+					Parts: attr.Parts,
+				})
+			}
+		case withDefaults && prop.Default != nil:
+			{
+				args = append(args, Arg{
+					Prop:  prop,
+					Value: prop.Default,
+				})
+			}
+		}
+	}
+	return args
+}
+
+// Slot finds the slot named name, where "" is the default slot.
+func (c *Component) Slot(name string) (Slot, bool) {
+	for _, s := range c.Slots {
+		if s.Name == name {
+			return s, true
+		}
+	}
+	return Slot{}, false
+}
+
+// Fills lists the content the COMPONENT node n passes, in the order the
+// component it uses declares its slots. Children outside of a @fill go to
+// the default slot.
+func (c *Component) Fills(n *wirparser.AstNode) []Fill {
+	u, _ := c.Import(n.TagName)
+	var fills []Fill
+	for _, slot := range u.Slots {
+		var children []*wirparser.AstNode
+		for _, child := range n.Children {
+			if child.Kind != wirparser.AstNodeKindFillDirective && slot.Name == "" {
+				children = append(children, child)
+			}
+			if child.Kind == wirparser.AstNodeKindFillDirective && child.Value == slot.Name {
+				children = child.Children
+			}
+		}
+		if len(children) > 0 {
+			fills = append(fills, Fill{
+				Slot:     slot,
+				Children: children,
+			})
+		}
+	}
+	return fills
+}
+
+func findFill(fills []Fill, s Slot) (Fill, bool) {
+	for _, fill := range fills {
+		if fill.Slot.Name == s.Name {
+			return fill, true
+		}
+	}
+	return Fill{}, false
+}
+
+func findAttr(n *wirparser.AstNode, key string) (wirparser.AstAttr, bool) {
+	for _, attr := range n.Attrs {
+		if attr.Key == key {
+			return attr, true
+		}
+	}
+	return wirparser.AstAttr{}, false
+}
+
+func hasInterpolation(parts []*wirparser.AstNode) bool {
+	for _, part := range parts {
+		if part.Kind == wirparser.AstNodeKindInterpolation {
+			return true
+		}
+	}
+	return false
+}
+
+// staticValue reads the text of an attribute passed to a prop of type t.
+func staticValue(t string, text string) (any, bool) {
+	switch t {
+	default:
+		{
+			return text, true
+		}
+	case "int":
+		{
+			i, err := strconv.Atoi(text)
+			return float64(i), err == nil
+		}
+	case "float":
+		{
+			f, err := strconv.ParseFloat(text, 64)
+			return f, err == nil
+		}
+	case "bool":
+		{
+			return text == "true", text == "true" || text == "false"
+		}
+	}
+}
+
+// IsEnum reports whether t names one of the enums of c.
+func (c *Component) IsEnum(t string) bool {
+	def, exists := c.Type(t)
+	return exists && def.IsEnum()
+}
+
+// ExprType resolves the type of an interpolated expression in scope.
+func (c *Component) ExprType(expr string, scope Scope) string {
+	parts := strings.Split(expr, ".")
+	t, bound := scope[parts[0]]
+	if !bound {
+		p, _ := c.Prop(parts[0])
+		t = p.Type
+	}
+	for _, name := range parts[1:] {
+		def, exists := c.Type(t)
+		if !exists {
+			return ""
+		}
+		t = ""
+		for _, f := range def.Fields {
+			if f.Name == name {
+				t = f.Type
+			}
+		}
+	}
+	return t
+}
+
+// ForList names the prop a @for directive iterates over.
+func ForList(n *wirparser.AstNode) string {
+	return Plural(n.Binding)
+}
+
+// InterpolationType returns the declared type of an interpolation, which
+// defaults to string when none is given.
+func InterpolationType(n *wirparser.AstNode) string {
+	if n.ValueType == "" {
+		return "string"
+	}
+	return n.ValueType
+}
+
+// enumSite is an interpolation checked once every enum is known.
+type enumSite struct {
+	n     *wirparser.AstNode
+	scope Scope
+}
+
+// HasDefaults reports whether any prop of c has a default.
+func (c *Component) HasDefaults() bool {
+	for _, p := range c.Props {
+		if p.Default != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Component) analyze() wirdiag.List {
+	var diags wirdiag.List
+	if len(c.Imports) != len(c.Ast.Imports) {
+		for _, imp := range c.Ast.Imports {
+			diags = append(diags, wirdiag.DiagnosticNew(imp.Span.Start, "@import('%s') can only be resolved when the file is built as part of its directory", imp.Value))
+		}
+		return diags
+	}
+	if c.Ast.Props != nil {
+		diags = append(diags, c.declare(c.Ast.Props)...)
+	}
+	var sites []enumSite
+	var walk func(n *wirparser.AstNode, parent *wirparser.AstNode, scope Scope)
+	walk = func(n *wirparser.AstNode, parent *wirparser.AstNode, scope Scope) {
+		if n.Kind == wirparser.AstNodeKindInterpolation || n.Kind == wirparser.AstNodeKindIfBranch || n.Kind == wirparser.AstNodeKindSwitchDirective {
+			c.resolveDeclared(n, scope)
+		}
+		switch n.Kind {
+		case wirparser.AstNodeKindForDirective:
+			{
+				diag := c.checkType(n.BindingType, n.Span)
+				if diag != nil {
+					diags = append(diags, diag)
+					return
+				}
+				diag = c.addProp(ForList(n), "[]"+n.BindingType, n.Span)
+				if diag != nil {
+					diags = append(diags, diag)
+				}
+				c.addType(n.BindingType)
+				inner := scope.With(n.Binding, n.BindingType)
+				for _, child := range n.Children {
+					walk(child, n, inner)
+				}
+				return
+			}
+		case wirparser.AstNodeKindInterpolation:
+			{
+				diag := c.addInterpolation(n, scope)
+				if diag != nil {
+					diags = append(diags, diag)
+				}
+				sites = append(sites, enumSite{
+					n:     n,
+					scope: scope,
+				})
+			}
+		case wirparser.AstNodeKindSwitchDirective:
+			{
+				diag := c.addSwitch(n, scope)
+				if diag != nil {
+					diags = append(diags, diag)
+				}
+			}
+		case wirparser.AstNodeKindComponent:
+			{
+				diags = append(diags, c.addUse(n, scope)...)
+			}
+		case wirparser.AstNodeKindSlotDirective:
+			{
+				diag := c.addSlot(n)
+				if diag != nil {
+					diags = append(diags, diag)
+				}
+			}
+		case wirparser.AstNodeKindFillDirective:
+			{
+				if parent.Kind != wirparser.AstNodeKindComponent {
+					diags = append(diags, wirdiag.DiagnosticNew(n.Span.Start, "@fill('%s') outside of a component", n.Value))
+				}
+			}
+		case wirparser.AstNodeKindIfBranch:
+			{
+				diag := c.addCondition(n, scope)
+				if diag != nil {
+					diags = append(diags, diag)
+				}
+			}
+		}
+		for _, attr := range n.Attrs {
+			for _, part := range attr.Parts {
+				walk(part, n, scope)
+			}
+		}
+		for _, child := range n.Children {
+			walk(child, n, scope)
+		}
+	}
+	walk(c.Ast.Root, nil, Scope{})
+	for _, site := range sites {
+		def, exists := c.Type(c.ExprType(site.n.Value, site.scope))
+		if exists && def.IsEnum() {
+			diags = append(diags, wirdiag.DiagnosticNew(site.n.Span.Start, "cannot interpolate %s of enum type %s, use @switch to render each value", site.n.Value, def.Name))
+		}
+	}
+	if c.Ast.Props != nil {
+		for _, n := range c.Ast.Props.Children {
+			diag := c.addDefault(n)
+			if diag != nil {
+				diags = append(diags, diag)
+			}
+		}
+	}
+	for _, s := range c.Slots {
+		if p, exists := c.Prop(s.Name); exists {
+			diags = append(diags, wirdiag.DiagnosticNew(s.Span.Start, "@slot('%s') has the same name as the prop at %s", s.Name, p.Span.Start.Str()))
+		}
+	}
+	if s, exists := c.Slot(""); exists {
+		for _, p := range c.Props {
+			if isDefaultSlotName(p.Name) {
+				diags = append(diags, wirdiag.DiagnosticNew(p.Span.Start, "prop %s has a name some targets give the @slot at %s, rename it", p.Name, s.Span.Start.Str()))
+			}
+		}
+		for _, named := range c.Slots {
+			if named.Name != "" && isDefaultSlotName(named.Name) {
+				diags = append(diags, wirdiag.DiagnosticNew(named.Span.Start, "@slot('%s') has a name some targets give the @slot at %s, rename it", named.Name, s.Span.Start.Str()))
+			}
+		}
+	}
+	return diags
+}
+
+// addSlot declares the @slot n. Each slot can only be declared once, as the
+// content passed to it is only rendered once.
+func (c *Component) addSlot(n *wirparser.AstNode) *wirdiag.Diagnostic {
+	if n.Value != "" && !isIdent(n.Value) {
+		return wirdiag.DiagnosticNew(n.Span.Start, "invalid slot name %s, expected a name such as footer", n.Value)
+	}
+	if s, exists := c.Slot(n.Value); exists {
+		return wirdiag.DiagnosticNew(n.Span.Start, "duplicate %s, already declared at %s", slotLabel(n.Value), s.Span.Start.Str())
+	}
+	c.Slots = append(c.Slots, Slot{
+		Name: n.Value,
+		Span: n.Span,
+	})
+	return nil
+}
+
+// isDefaultSlotName reports whether a target could name a field of the
+// default slot the same as name.
+func isDefaultSlotName(name string) bool {
+	for _, reserved := range defaultSlotNames {
+		if Pascal(name) == Pascal(reserved) {
+			return true
+		}
+	}
+	return false
+}
+
+func slotLabel(name string) string {
+	if name == "" {
+		return "@slot"
+	}
+	return "@slot('" + name + "')"
+}
+
+// declare adds the props of a @props declaration.
+func (c *Component) declare(decl *wirparser.AstNode) wirdiag.List {
+	var diags wirdiag.List
+	for _, n := range decl.Children {
+		if !isIdent(n.Value) {
+			diags = append(diags, wirdiag.DiagnosticNew(n.Span.Start, "invalid prop name %s, expected a name such as title", n.Value))
+			continue
+		}
+		c.Props = append(c.Props, Field{
+			Name: n.Value,
+			Type: n.ValueType,
+			Span: n.Span,
+		})
+		diag := c.checkType(n.ValueType, n.Span)
+		if diag != nil {
+			diags = append(diags, diag)
+			continue
+		}
+		c.addType(ListElem(n.ValueType))
+	}
+	return diags
+}
+
+// resolveDeclared gives a value left without a type the type of the prop
+// @props declares for it.
+func (c *Component) resolveDeclared(n *wirparser.AstNode, scope Scope) {
+	if n.ValueType != "" || n.Value == "" || scope.Has(n.Value) {
+		return
+	}
+	p, exists := c.Prop(n.Value)
+	if exists && c.Ast.Props != nil {
+		n.ValueType = p.Type
+	}
+}
+
+// addDefault checks the default of a declared prop against its type. Lists
+// may only default to [] and enums to one of their @case values.
+func (c *Component) addDefault(n *wirparser.AstNode) *wirdiag.Diagnostic {
+	if n.Default == "" || c.checkType(n.ValueType, n.Span) != nil {
+		return nil
+	}
+	var val any
+	var ok bool
+	expected := ""
+	switch t := n.ValueType; {
+	default:
+		{
+			def, exists := c.Type(t)
+			if !exists || !def.IsEnum() {
+				return wirdiag.DiagnosticNew(n.Span.Start, "%s of type %s can't have a default, only primitives, lists and enums can", n.Value, t)
+			}
+			var s string
+			s, ok = unquoteLiteral(n.Default)
+			ok = ok && containsStr(def.Values, s)
+			val = s
+			expected = "one of '" + strings.Join(def.Values, "' | '") + "'"
+		}
+	case IsList(t):
+		{
+			val, ok = []any{}, n.Default == "[]"
+			expected = "[]"
+		}
+	case t == "string":
+		{
+			val, ok = unquoteLiteral(n.Default)
+			expected = "a quoted string such as 'text'"
+		}
+	case t == "int":
+		{
+			i, err := strconv.Atoi(n.Default)
+			val, ok = float64(i), err == nil
+			expected = "a whole number such as 0"
+		}
+	case t == "float":
+		{
+			f, err := strconv.ParseFloat(n.Default, 64)
+			val, ok = f, err == nil
+			expected = "a number such as 1.5"
+		}
+	case t == "bool":
+		{
+			val, ok = n.Default == "true", n.Default == "true" || n.Default == "false"
+			expected = "true or false"
+		}
+	}
+	if !ok {
+		return wirdiag.DiagnosticNew(n.Span.Start, "invalid default %s for %s, expected %s", n.Default, n.Value, expected)
+	}
+	for i := range c.Props {
+		if c.Props[i].Name == n.Value {
+			c.Props[i].Default = val
+		}
+	}
+	return nil
+}
+
+// unquoteLiteral reads a quoted string literal, in which a backslash escapes
+// the character following it.
+func unquoteLiteral(s string) (string, bool) {
+	if len(s) < 2 || (s[0] != '\'' && s[0] != '"') || s[len(s)-1] != s[0] {
+		return "", false
+	}
+	var sb strings.Builder
+	escaped := false
+	for _, r := range s[1 : len(s)-1] {
+		if r == '\\' && !escaped {
+			escaped = true
+			continue
+		}
+		escaped = false
+		sb.WriteRune(r)
+	}
+	return sb.String(), true
+}
+
+func (c *Component) addInterpolation(n *wirparser.AstNode, scope Scope) *wirdiag.Diagnostic {
+	t := InterpolationType(n)
+	diag := c.checkType(t, n.Span)
+	if diag != nil {
+		return diag
+	}
+	parts := strings.Split(n.Value, ".")
+	for _, part := range parts {
+		if !isIdent(part) {
+			return wirdiag.DiagnosticNew(n.Span.Start, "unsupported expression %s, expected a name such as user or user.name", n.Value)
+		}
+	}
+	bindingType, bound := scope[parts[0]]
+	if !bound {
+		if len(parts) > 1 {
+			return wirdiag.DiagnosticNew(n.Span.Start, "cannot infer the type of %s, only fields of @for bindings can be accessed", parts[0])
+		}
+		return c.addProp(parts[0], t, n.Span)
+	}
+	if len(parts) == 1 {
+		if !IsPrimitive(bindingType) {
+			return wirdiag.DiagnosticNew(n.Span.Start, "cannot interpolate %s of type %s, interpolate one of its fields instead", parts[0], bindingType)
+		}
+		if t != bindingType && n.ValueType != "" {
+			return wirdiag.DiagnosticNew(n.Span.Start, "%s is bound as %s but interpolated as %s", parts[0], bindingType, t)
+		}
+		return nil
+	}
+	if len(parts) > 2 {
+		return wirdiag.DiagnosticNew(n.Span.Start, "nested field access %s is not supported, only one level such as %s.%s", n.Value, parts[0], parts[1])
+	}
+	if IsPrimitive(bindingType) {
+		return wirdiag.DiagnosticNew(n.Span.Start, "%s is a %s and has no field %s", parts[0], bindingType, parts[1])
+	}
+	def := c.addType(bindingType)
+	if def.IsEnum() {
+		return wirdiag.DiagnosticNew(n.Span.Start, "%s is an enum and has no field %s", parts[0], parts[1])
+	}
+	for _, f := range def.Fields {
+		if f.Name == parts[1] {
+			if f.Type != t {
+				return wirdiag.DiagnosticNew(n.Span.Start, "%s is used as %s here but as %s at %s", n.Value, t, f.Type, f.Span.Start.Str())
+			}
+			return nil
+		}
+	}
+	def.Fields = append(def.Fields, Field{
+		Name: parts[1],
+		Type: t,
+		Span: n.Span,
+	})
+	return nil
+}
+
+// addCondition checks the condition of an @if or @elseif branch, which is a
+// bool whether or not its type is written out. An @else has no condition.
+func (c *Component) addCondition(n *wirparser.AstNode, scope Scope) *wirdiag.Diagnostic {
+	if n.Value == "" {
+		return nil
+	}
+	if n.ValueType != "" && n.ValueType != "bool" {
+		return wirdiag.DiagnosticNew(n.Span.Start, "condition %s must be a bool but is declared as %s", n.Value, n.ValueType)
+	}
+	cond := *n
+	cond.ValueType = "bool"
+	return c.addInterpolation(&cond, scope)
+}
+
+// addSwitch checks the value of a @switch, which is a string unless a
+// capitalised type makes it an enum of its @case literals.
+func (c *Component) addSwitch(n *wirparser.AstNode, scope Scope) *wirdiag.Diagnostic {
+	t := InterpolationType(n)
+	if t == "string" {
+		return c.addInterpolation(n, scope)
+	}
+	diag := c.checkType(t, n.Span)
+	if diag != nil {
+		return diag
+	}
+	if IsPrimitive(t) || IsList(t) {
+		return wirdiag.DiagnosticNew(n.Span.Start, "@switch value %s must be a string or an enum type such as Status, not %s", n.Value, t)
+	}
+	def := c.addType(t)
+	if len(def.Fields) > 0 {
+		return wirdiag.DiagnosticNew(n.Span.Start, "%s is switched on as an enum but has a field %s at %s", t, def.Fields[0].Name, def.Fields[0].Span.Start.Str())
+	}
+	for _, arm := range n.Children {
+		if arm.Kind != wirparser.AstNodeKindSwitchCase || containsStr(def.Values, arm.Value) {
+			continue
+		}
+		if !isIdent(Camel(arm.Value)) {
+			return wirdiag.DiagnosticNew(arm.Span.Start, "case '%s' of enum %s must be a name made of letters, digits, '-' and '_'", arm.Value, t)
+		}
+		def.Values = append(def.Values, arm.Value)
+	}
+	bindingType, bound := scope[n.Value]
+	if bound {
+		if bindingType != t {
+			return wirdiag.DiagnosticNew(n.Span.Start, "%s is bound as %s but switched on as %s", n.Value, bindingType, t)
+		}
+		return nil
+	}
+	return c.addInterpolation(n, scope)
+}
+
+// addUse checks the props the COMPONENT node n passes against those the
+// component it uses declares. Only string, int, float and bool props can be
+// passed, since user types aren't shared between components. Interpolations
+// passed without a type take the type of their prop. Children have to go to
+// a slot the component declares.
+func (c *Component) addUse(n *wirparser.AstNode, scope Scope) wirdiag.List {
+	u, exists := c.Import(n.TagName)
+	if !exists {
+		return wirdiag.List{wirdiag.DiagnosticNew(n.Span.Start, "unknown component %s, import it with @import('%s.wir')", n.TagName, Snake(n.TagName))}
+	}
+	var diags wirdiag.List
+	filled := map[string]*wirparser.AstNode{}
+	_, hasDefault := u.Slot("")
+	for _, child := range n.Children {
+		if child.Kind != wirparser.AstNodeKindFillDirective {
+			if !hasDefault {
+				diags = append(diags, wirdiag.DiagnosticNew(child.Span.Start, "%s has no @slot and can't be given children", n.TagName))
+				hasDefault = true
+			}
+			continue
+		}
+		if _, exists := u.Slot(child.Value); !exists {
+			diags = append(diags, wirdiag.DiagnosticNew(child.Span.Start, "%s has no slot %s", n.TagName, child.Value))
+			continue
+		}
+		if prev, exists := filled[child.Value]; exists {
+			diags = append(diags, wirdiag.DiagnosticNew(child.Span.Start, "duplicate @fill('%s'), already given at %s", child.Value, prev.Span.Start.Str()))
+			continue
+		}
+		filled[child.Value] = child
+	}
+	for _, attr := range n.Attrs {
+		prop, exists := u.Prop(attr.Key)
+		if !exists {
+			diags = append(diags, wirdiag.DiagnosticNew(attr.Span.Start, "%s has no prop %s", n.TagName, attr.Key))
+			continue
+		}
+		if !IsPrimitive(prop.Type) {
+			diags = append(diags, wirdiag.DiagnosticNew(attr.Span.Start, "cannot pass %s of type %s to %s, only string, int, float and bool props can be passed", attr.Key, prop.Type, n.TagName))
+			continue
+		}
+		if attr.IsBool() {
+			if prop.Type != "bool" {
+				diags = append(diags, wirdiag.DiagnosticNew(attr.Span.Start, "%s of %s has type %s and needs a value", attr.Key, n.TagName, prop.Type))
+			}
+			continue
+		}
+		if part, ok := singleInterpolation(attr.Parts); ok {
+			c.resolveDeclared(part, scope)
+			if part.ValueType == "" {
+				part.ValueType = prop.Type
+			}
+			if part.ValueType != prop.Type {
+				diags = append(diags, wirdiag.DiagnosticNew(part.Span.Start, "%s is passed as %s but %s of %s has type %s", part.Value, part.ValueType, attr.Key, n.TagName, prop.Type))
+			}
+			continue
+		}
+		if hasInterpolation(attr.Parts) {
+			if prop.Type != "string" {
+				diags = append(diags, wirdiag.DiagnosticNew(attr.Span.Start, "%s of %s has type %s and can't be given text mixed with values", attr.Key, n.TagName, prop.Type))
+			}
+			continue
+		}
+		text := joinParts(attr.Parts, func(s string) string { return s }, nil)
+		if _, ok := staticValue(prop.Type, text); !ok {
+			diags = append(diags, wirdiag.DiagnosticNew(attr.Span.Start, "invalid value '%s' for %s of %s, expected %s", text, attr.Key, n.TagName, prop.Type))
+		}
+	}
+	for _, prop := range u.Props {
+		if _, passed := findAttr(n, prop.Name); !passed && prop.Default == nil {
+			diags = append(diags, wirdiag.DiagnosticNew(n.Span.Start, "%s is missing prop %s: %s", n.TagName, prop.Name, prop.Type))
+		}
+	}
+	return diags
+}
+
+func (c *Component) addProp(name string, t string, span wirtokenizer.Span) *wirdiag.Diagnostic {
+	p, exists := c.Prop(name)
+	if !exists && c.Ast.Props != nil {
+		return wirdiag.DiagnosticNew(span.Start, "%s is not a declared prop, add %s: %s to @props", name, name, t)
+	}
+	if exists {
+		if c.Ast.Props != nil && p.Type != t {
+			return wirdiag.DiagnosticNew(span.Start, "%s is declared as %s at %s but used as %s here", name, p.Type, p.Span.Start.Str(), t)
+		}
+		if p.Type != t {
+			return wirdiag.DiagnosticNew(span.Start, "%s is used as %s here but as %s at %s", name, t, p.Type, p.Span.Start.Str())
+		}
+		return nil
+	}
+	c.Props = append(c.Props, Field{
+		Name: name,
+		Type: t,
+		Span: span,
+	})
+	return nil
+}
+
+func (c *Component) addType(name string) *TypeDef {
+	if IsPrimitive(name) {
+		return nil
+	}
+	def, exists := c.Type(name)
+	if exists {
+		return def
+	}
+	def = &TypeDef{
+		Name: name,
+	}
+	c.Types = append(c.Types, def)
+	return def
+}
+
+// checkType accepts the primitive types and capitalised user types.
+func (c *Component) checkType(t string, span wirtokenizer.Span) *wirdiag.Diagnostic {
+	elem := ListElem(t)
+	if IsPrimitive(elem) {
+		return nil
+	}
+	if isIdent(elem) && elem[0] >= 'A' && elem[0] <= 'Z' {
+		return nil
+	}
+	return wirdiag.DiagnosticNew(span.Start, "unknown type %s, expected string, int, float, bool or a capitalised type name", t)
+}
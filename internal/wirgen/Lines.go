@@ -0,0 +1,22 @@
+package wirgen
+
+import "strings"
+
+// lines accumulates source text one indented line at a time.
+type lines struct {
+	sb     strings.Builder
+	indent int
+	unit   string
+}
+
+func (l *lines) line(s string) {
+	if s == "" {
+		l.sb.WriteString("\n")
+		return
+	}
+	l.sb.WriteString(strings.Repeat(l.unit, l.indent) + s + "\n")
+}
+
+func (l *lines) out() string {
+	return l.sb.String()
+}
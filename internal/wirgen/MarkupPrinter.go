@@ -0,0 +1,277 @@
+package wirgen
+
+import "github.com/phillip-england/wir/internal/wirparser"
+
+// markupDialect is implemented by targets whose output is html with their own
+// syntax for the dynamic parts, such as JSX or a Vue template. The printer
+// walks the tree and asks the dialect how to spell each dynamic piece.
+type markupDialect interface {
+	// attr renders a single attribute including its leading space.
+	attr(a wirparser.AstAttr, scope Scope) string
+	// text renders a STRING node as element content.
+	text(n *wirparser.AstNode, scope Scope) string
+	// loop renders a @for directive, calling back into the printer for the body.
+	loop(p *markupPrinter, n *wirparser.AstNode, scope Scope)
+	// cond renders an @if directive and its branches.
+	cond(p *markupPrinter, n *wirparser.AstNode, scope Scope)
+	// match renders a @switch directive and its arms.
+	match(p *markupPrinter, n *wirparser.AstNode, scope Scope)
+	// component renders the use of an imported component.
+	component(p *markupPrinter, n *wirparser.AstNode, scope Scope)
+	// slot renders a @slot along with its fallback content.
+	slot(p *markupPrinter, n *wirparser.AstNode, scope Scope)
+	// voidEnd closes a void element such as img, either ">" or " />".
+	voidEnd() string
+}
+
+// markupPrinter writes indented markup for a markupDialect.
+type markupPrinter struct {
+	lines
+	d markupDialect
+}
+
+func markupPrinterNew(d markupDialect, indent int) *markupPrinter {
+	return &markupPrinter{
+		lines: lines{
+			indent: indent,
+			unit:   "  ",
+		},
+		d: d,
+	}
+}
+
+func (p *markupPrinter) nodes(nodes []*wirparser.AstNode, scope Scope) {
+	for _, n := range nodes {
+		p.node(n, scope)
+	}
+}
+
+func (p *markupPrinter) node(n *wirparser.AstNode, scope Scope) {
+	switch n.Kind {
+	default:
+		{
+			p.nodes(n.Children, scope)
+		}
+	case wirparser.AstNodeKindElement:
+		{
+			p.element(n, scope, "")
+		}
+	case wirparser.AstNodeKindString:
+		{
+			p.line(p.d.text(n, scope))
+		}
+	case wirparser.AstNodeKindForDirective:
+		{
+			p.d.loop(p, n, scope)
+		}
+	case wirparser.AstNodeKindIfDirective:
+		{
+			p.d.cond(p, n, scope)
+		}
+	case wirparser.AstNodeKindSwitchDirective:
+		{
+			p.d.match(p, n, scope)
+		}
+	case wirparser.AstNodeKindComponent:
+		{
+			p.d.component(p, n, scope)
+		}
+	case wirparser.AstNodeKindSlotDirective:
+		{
+			p.d.slot(p, n, scope)
+		}
+	}
+}
+
+// element prints n, adding extra to its attributes. Elements holding a single
+// string are kept on one line.
+func (p *markupPrinter) element(n *wirparser.AstNode, scope Scope, extra string) {
+	open := "<" + n.TagName + extra
+	for _, attr := range n.Attrs {
+		open += p.d.attr(attr, scope)
+	}
+	if isVoidElement(n.TagName) {
+		p.line(open + p.d.voidEnd())
+		return
+	}
+	open += ">"
+	end := "</" + n.TagName + ">"
+	if len(n.Children) == 0 {
+		p.line(open + end)
+		return
+	}
+	if len(n.Children) == 1 && n.Children[0].Kind == wirparser.AstNodeKindString {
+		p.line(open + p.d.text(n.Children[0], scope) + end)
+		return
+	}
+	p.line(open)
+	p.indent++
+	p.nodes(n.Children, scope)
+	p.indent--
+	p.line(end)
+}
+
+// use prints the use of a component whose opening tag is open and whose
+// closing tag is end. Content for the default slot is nested as is and named
+// prints the content for each named slot. A use without content is closed
+// with empty instead.
+func (p *markupPrinter) use(open string, end string, empty string, fills []Fill, scope Scope, named func(fill Fill)) {
+	if len(fills) == 0 {
+		p.line(open + empty)
+		return
+	}
+	p.line(open + ">")
+	p.indent++
+	for _, fill := range fills {
+		if fill.Slot.Name == "" {
+			p.nodes(fill.Children, scope)
+			continue
+		}
+		named(fill)
+	}
+	p.indent--
+	p.line(end)
+}
+
+// branches prints the branches of an @if directive or the arms of a @switch,
+// each under the line head returns for it, and closes them with end. head is
+// given the index of the branch, whose Value is empty for an @else.
+func (p *markupPrinter) branches(branches []*wirparser.AstNode, scope Scope, head func(i int, branch *wirparser.AstNode) string, end string) {
+	for i, branch := range branches {
+		p.line(head(i, branch))
+		p.indent++
+		p.nodes(branch.Children, scope)
+		p.indent--
+	}
+	p.line(end)
+}
+
+// slotElement turns the @slot n into a <slot> element holding its fallback
+// content, for targets whose slots work like those of web components.
+func slotElement(n *wirparser.AstNode) *wirparser.AstNode {
+	slot := &wirparser.AstNode{
+		Kind:     wirparser.AstNodeKindElement,
+		TagName:  "slot",
+		Children: n.Children,
+		Span:     n.Span,
+	}
+	if n.Value != "" {
+		slot.Attrs = []wirparser.AstAttr{textAttr("name", n.Value)}
+	}
+	return slot
+}
+
+// slotted returns the element assigning the content for a named slot of a
+// custom element to it with the slot attribute. A single element carries the
+// attribute itself and anything else is wrapped in a div that doesn't affect
+// layout.
+func slotted(fill Fill) *wirparser.AstNode {
+	attr := textAttr("slot", fill.Slot.Name)
+	if len(fill.Children) == 1 && fill.Children[0].Kind == wirparser.AstNodeKindElement {
+		el := *fill.Children[0]
+		el.Attrs = append([]wirparser.AstAttr{attr}, el.Attrs...)
+		return &el
+	}
+	return &wirparser.AstNode{
+		Kind:     wirparser.AstNodeKindElement,
+		TagName:  "div",
+		Attrs:    []wirparser.AstAttr{attr, textAttr("style", "display: contents")},
+		Children: fill.Children,
+	}
+}
+
+func textAttr(key string, value string) wirparser.AstAttr {
+	return wirparser.AstAttr{
+		Key: key,
+		Parts: []*wirparser.AstNode{{
+			Kind: wirparser.AstNodeKindText,
+			Text: value,
+		}},
+	}
+}
+
+// switchArms orders the arms of a @switch so that any @default comes last.
+func switchArms(n *wirparser.AstNode) []*wirparser.AstNode {
+	var arms []*wirparser.AstNode
+	var fallback *wirparser.AstNode
+	for _, arm := range n.Children {
+		if arm.Kind == wirparser.AstNodeKindSwitchDefault {
+			fallback = arm
+			continue
+		}
+		arms = append(arms, arm)
+	}
+	if fallback != nil {
+		arms = append(arms, fallback)
+	}
+	return arms
+}
+
+// coversSwitch reports whether the @switch n handles every value it can be
+// given, either with a @default or by having a case for each value of its
+// enum. Targets whose switches must be exhaustive add an empty default when
+// it doesn't.
+func coversSwitch(c *Component, n *wirparser.AstNode) bool {
+	var cases []string
+	for _, arm := range n.Children {
+		if arm.Kind == wirparser.AstNodeKindSwitchDefault {
+			return true
+		}
+		cases = append(cases, arm.Value)
+	}
+	def, exists := c.Type(InterpolationType(n))
+	if !exists || !def.IsEnum() {
+		return false
+	}
+	for _, v := range def.Values {
+		if !containsStr(cases, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// switchAsIf lowers a @switch to an @if chain for targets without a switch of
+// their own. Each @case becomes a branch whose condition is the comparison eq
+// writes for its literal and the @default becomes the @else.
+func switchAsIf(n *wirparser.AstNode, eq func(lit string) string) *wirparser.AstNode {
+	out := &wirparser.AstNode{
+		Kind: wirparser.AstNodeKindIfDirective,
+		Span: n.Span,
+	}
+	for _, arm := range switchArms(n) {
+		branch := &wirparser.AstNode{
+			Kind:     wirparser.AstNodeKindIfBranch,
+			Children: arm.Children,
+			Span:     arm.Span,
+		}
+		if arm.Kind == wirparser.AstNodeKindSwitchCase {
+			branch.Value = eq(arm.Value)
+		}
+		out.Children = append(out.Children, branch)
+	}
+	return out
+}
+
+// joinParts renders the TEXT and INTERPOLATION parts of a string or attribute
+// value, passing each through lit or expr.
+func joinParts(parts []*wirparser.AstNode, lit func(s string) string, expr func(n *wirparser.AstNode) string) string {
+	out := ""
+	for _, part := range parts {
+		if part.Kind == wirparser.AstNodeKindInterpolation {
+			out += expr(part)
+			continue
+		}
+		out += lit(part.Text)
+	}
+	return out
+}
+
+// singleInterpolation returns the interpolation making up the whole of parts,
+// if there is one.
+func singleInterpolation(parts []*wirparser.AstNode) (*wirparser.AstNode, bool) {
+	if len(parts) == 1 && parts[0].Kind == wirparser.AstNodeKindInterpolation {
+		return parts[0], true
+	}
+	return nil, false
+}
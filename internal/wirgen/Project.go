@@ -0,0 +1,129 @@
+package wirgen
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/phillip-england/wir/internal/soak"
+	"github.com/phillip-england/wir/internal/wherr"
+	"github.com/phillip-england/wir/internal/wirdiag"
+)
+
+// Project holds the .wir files of a directory so that @import can resolve
+// one component from another. Import paths are relative to the importing
+// file, and each component is analyzed once, after the components it
+// imports.
+type Project struct {
+	files   map[string]projectFile
+	built   map[string]*Component
+	errs    map[string]error
+	loading []string
+}
+
+type projectFile struct {
+	path string
+	rel  string
+	src  string
+}
+
+func ProjectNew() *Project {
+	return &Project{
+		files: map[string]projectFile{},
+		built: map[string]*Component{},
+		errs:  map[string]error{},
+	}
+}
+
+// ProjectNewFromVfs adds every .wir file of vfs under its path relative to
+// the working directory. The directory of vfs is the root of the project.
+func ProjectNewFromVfs(vfs *soak.Vfs) *Project {
+	p := ProjectNew()
+	root := path.Clean(vfs.Path) + "/"
+	vfs.IterAssets(func(a *soak.VirtualAsset) bool {
+		if a.Ext == ".wir" {
+			p.add(a.RelPath, strings.TrimPrefix(a.Path, root), a.Text)
+		}
+		return true
+	})
+	return p
+}
+
+// Add adds the file at filePath, which is also its path from the root of
+// the project.
+func (p *Project) Add(filePath string, src string) {
+	p.add(filePath, path.Clean(filePath), src)
+}
+
+func (p *Project) add(filePath string, rel string, src string) {
+	p.files[path.Clean(filePath)] = projectFile{
+		path: filePath,
+		rel:  rel,
+		src:  src,
+	}
+}
+
+// Component analyzes the file added at filePath along with everything it
+// imports. Problems in the template come back as a wirdiag.List.
+func (p *Project) Component(filePath string) (*Component, error) {
+	key := path.Clean(filePath)
+	if c, exists := p.built[key]; exists {
+		return c, nil
+	}
+	if err, exists := p.errs[key]; exists {
+		return nil, err
+	}
+	if _, exists := p.files[key]; !exists {
+		return nil, wherr.Err(wherr.Here(), "%s is not a .wir file of the project", filePath)
+	}
+	c, err := p.load(key)
+	if err != nil {
+		p.errs[key] = err
+		return nil, err
+	}
+	p.built[key] = c
+	return c, nil
+}
+
+func (p *Project) load(key string) (*Component, error) {
+	f := p.files[key]
+	c, err := componentParse(f.path, f.src)
+	if err != nil {
+		return nil, err
+	}
+	c.RelPath = f.rel
+	p.loading = append(p.loading, key)
+	defer func() {
+		p.loading = p.loading[:len(p.loading)-1]
+	}()
+	var diags wirdiag.List
+	for _, imp := range c.Ast.Imports {
+		target := path.Join(path.Dir(key), imp.Value)
+		if path.Ext(target) == "" {
+			target += ".wir"
+		}
+		if i := slices.Index(p.loading, target); i >= 0 {
+			cycle := append(append([]string{}, p.loading[i:]...), target)
+			diags = append(diags, wirdiag.DiagnosticNew(imp.Span.Start, "import cycle %s", strings.Join(cycle, " -> ")))
+			continue
+		}
+		if _, exists := p.files[target]; !exists {
+			diags = append(diags, wirdiag.DiagnosticNew(imp.Span.Start, "cannot find %s", target))
+			continue
+		}
+		dep, err := p.Component(target)
+		if err != nil {
+			diags = append(diags, wirdiag.DiagnosticNew(imp.Span.Start, "%s has errors, fix them to import it", target))
+			continue
+		}
+		if other, exists := c.Import(dep.Name); exists {
+			diags = append(diags, wirdiag.DiagnosticNew(imp.Span.Start, "%s is already imported from %s", dep.Name, other.Path))
+			continue
+		}
+		c.Imports = append(c.Imports, dep)
+	}
+	if len(diags) > 0 {
+		return nil, diags.Attach(f.path, f.src)
+	}
+	return c, c.check()
+}
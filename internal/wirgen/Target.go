@@ -0,0 +1,67 @@
+package wirgen
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/phillip-england/wir/internal/wherr"
+)
+
+// Target turns a Component into source for one output platform.
+type Target interface {
+	Ext() string
+	Generate(c *Component) (string, error)
+}
+
+// TargetOptions carries the command line settings a target may need. Module
+// is the Go module path the go and templ output is written under, which
+// components importing others need to import their packages.
+type TargetOptions struct {
+	Props   map[string]any
+	Package string
+	Module  string
+}
+
+// TargetNested is implemented by targets that write each component into its
+// own directory, such as Go where every component is a package.
+type TargetNested interface {
+	Dir(c *Component) string
+}
+
+type TargetFactory func(opts TargetOptions) (Target, error)
+
+var targets = map[string]TargetFactory{
+	"html":         TargetHtmlNew,
+	"go":           TargetGoNew,
+	"react":        TargetReactNew,
+	"vue":          TargetVueNew,
+	"svelte":       TargetSvelteNew,
+	"solid":        TargetSolidNew,
+	"webcomponent": TargetWebComponentNew,
+	"lit":          TargetLitNew,
+	"swiftui":      TargetSwiftUINew,
+	"compose":      TargetComposeNew,
+	"flutter":      TargetFlutterNew,
+	"templ":        TargetTemplNew,
+	"jinja":        TargetJinjaNew,
+	"handlebars":   TargetHandlebarsNew,
+	"mustache":     TargetMustacheNew,
+	"blade":        TargetBladeNew,
+}
+
+func TargetNew(name string, opts TargetOptions) (Target, error) {
+	factory, exists := targets[name]
+	if !exists {
+		return nil, wherr.Err(wherr.Here(), "unknown target %s, expected one of: %s", name, strings.Join(TargetNames(), ", "))
+	}
+	return factory(opts)
+}
+
+func TargetNames() []string {
+	var names []string
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
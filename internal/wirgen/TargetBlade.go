@@ -0,0 +1,210 @@
+package wirgen
+
+import (
+	"strings"
+
+	"github.com/phillip-england/wir/internal/wirparser"
+)
+
+// TargetBlade generates an anonymous Laravel Blade component. Its props are
+// declared with @props and user types are read as objects, so user.name is
+// written as $user->name. Imported components are used as <x-name> tags. The
+// default slot is Blade's $slot and named slots are passed with <x-slot>,
+// declared as props that are null until filled.
+type TargetBlade struct{}
+
+func TargetBladeNew(opts TargetOptions) (Target, error) {
+	return &TargetBlade{}, nil
+}
+
+func (t *TargetBlade) Ext() string {
+	return ".blade.php"
+}
+
+func (t *TargetBlade) Generate(c *Component) (string, error) {
+	d := &bladeDialect{
+		c: c,
+	}
+	p := markupPrinterNew(d, 0)
+	p.nodes(c.Ast.Root.Children, Scope{})
+	var sb strings.Builder
+	sb.WriteString(header(c, "{{--", " --}}"))
+	sb.WriteString(contextComment(c, "{{--", "--}}"))
+	var names []string
+	for _, prop := range c.Props {
+		if prop.Default != nil {
+			names = append(names, phpString(prop.Name)+" => "+literal(prop.Default, phpString, "[]"))
+			continue
+		}
+		names = append(names, phpString(prop.Name))
+	}
+	for _, s := range c.Slots {
+		if s.Name != "" {
+			names = append(names, phpString(s.Name)+" => null")
+		}
+	}
+	if len(names) > 0 {
+		sb.WriteString("@props([" + strings.Join(names, ", ") + "])\n")
+	}
+	sb.WriteString(p.out())
+	return sb.String(), nil
+}
+
+// phpString quotes s as a single quoted PHP string.
+func phpString(s string) string {
+	return "'" + strings.NewReplacer("\\", "\\\\", "'", "\\'").Replace(s) + "'"
+}
+
+type bladeDialect struct {
+	c *Component
+}
+
+// path translates a wir expression such as user.name into PHP.
+func (d *bladeDialect) path(value string) string {
+	return "$" + strings.ReplaceAll(value, ".", "->")
+}
+
+func (d *bladeDialect) expr(value string, scope Scope) string {
+	if d.c.ExprType(value, scope) == "bool" {
+		return "{{ " + d.path(value) + " ? 'true' : 'false' }}"
+	}
+	return "{{ " + d.path(value) + " }}"
+}
+
+// attr toggles an attribute made of a lone bool interpolation with @if. The
+// directives are kept apart from the attribute name since Blade ignores an @
+// following a word character.
+func (d *bladeDialect) attr(a wirparser.AstAttr, scope Scope) string {
+	if a.IsBool() {
+		return " " + a.Key
+	}
+	if n, ok := singleInterpolation(a.Parts); ok && d.c.ExprType(n.Value, scope) == "bool" {
+		return " @if(" + d.path(n.Value) + ") " + a.Key + " @endif"
+	}
+	return " " + a.Key + "=\"" + joinParts(a.Parts, bladeText, func(n *wirparser.AstNode) string {
+		return d.expr(n.Value, scope)
+	}) + "\""
+}
+
+func (d *bladeDialect) text(n *wirparser.AstNode, scope Scope) string {
+	return joinParts(n.Children, bladeText, func(part *wirparser.AstNode) string {
+		return d.expr(part.Value, scope)
+	})
+}
+
+func (d *bladeDialect) loop(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	p.line("@foreach(" + d.path(ForList(n)) + " as " + d.path(n.Binding) + ")")
+	p.indent++
+	p.nodes(n.Children, scope.With(n.Binding, n.BindingType))
+	p.indent--
+	p.line("@endforeach")
+}
+
+func (d *bladeDialect) cond(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	p.branches(n.Children, scope, func(i int, branch *wirparser.AstNode) string {
+		switch {
+		default:
+			{
+				return "@elseif(" + d.path(branch.Value) + ")"
+			}
+		case i == 0:
+			{
+				return "@if(" + d.path(branch.Value) + ")"
+			}
+		case branch.Value == "":
+			{
+				return "@else"
+			}
+		}
+	}, "@endif")
+}
+
+func (d *bladeDialect) match(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	p.line("@switch(" + d.path(n.Value) + ")")
+	p.indent++
+	for _, arm := range switchArms(n) {
+		if arm.Kind == wirparser.AstNodeKindSwitchDefault {
+			p.line("@default")
+		} else {
+			p.line("@case('" + strings.NewReplacer("\\", "\\\\", "'", "\\'").Replace(arm.Value) + "')")
+		}
+		p.indent++
+		p.nodes(arm.Children, scope)
+		if arm.Kind == wirparser.AstNodeKindSwitchCase {
+			p.line("@break")
+		}
+		p.indent--
+	}
+	p.indent--
+	p.line("@endswitch")
+}
+
+// component binds the props passed as values with :key, which Blade
+// evaluates as PHP, and names them in kebab case as Laravel expects.
+func (d *bladeDialect) component(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	u, _ := d.c.Import(n.TagName)
+	tag := "x-" + strings.ReplaceAll(templateName(u, ""), "/", ".")
+	out := "<" + tag
+	for _, arg := range d.c.Args(n, false) {
+		key := wcAttrName(arg.Prop.Name)
+		s, isString := arg.Value.(string)
+		switch {
+		default:
+			{
+				out += " :" + key + "=\"" + literal(arg.Value, phpString, "[]") + "\""
+			}
+		case arg.Parts != nil:
+			{
+				if part, ok := singleInterpolation(arg.Parts); ok {
+					out += " :" + key + "=\"" + d.path(part.Value) + "\""
+					continue
+				}
+				out += d.attr(wirparser.AstAttr{Key: key, Parts: arg.Parts}, scope)
+			}
+		case isString:
+			{
+				out += " " + key + "=\"" + bladeText(s) + "\""
+			}
+		}
+	}
+	p.use(out, "</"+tag+">", " />", d.c.Fills(n), scope, func(fill Fill) {
+		p.line("<x-slot:" + wcAttrName(fill.Slot.Name) + ">")
+		p.indent++
+		p.nodes(fill.Children, scope)
+		p.indent--
+		p.line("</x-slot>")
+	})
+}
+
+// slot echoes the content of the slot, which Blade has already escaped,
+// rendering the children of n instead when the slot wasn't filled.
+func (d *bladeDialect) slot(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	value := d.path(slotName(Slot{Name: n.Value}, "slot"))
+	if len(n.Children) == 0 {
+		p.line("{{ " + value + " }}")
+		return
+	}
+	if n.Value == "" {
+		p.line("@if(" + value + "->isNotEmpty())")
+	} else {
+		p.line("@if(isset(" + value + "))")
+	}
+	p.indent++
+	p.line("{{ " + value + " }}")
+	p.indent--
+	p.line("@else")
+	p.indent++
+	p.nodes(n.Children, scope)
+	p.indent--
+	p.line("@endif")
+}
+
+func (d *bladeDialect) voidEnd() string {
+	return ">"
+}
+
+// bladeText escapes static text, writing @ as an entity so it can't start a
+// directive.
+func bladeText(s string) string {
+	return strings.ReplaceAll(braceSafeText(s), "@", "&#64;")
+}
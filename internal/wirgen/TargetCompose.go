@@ -0,0 +1,492 @@
+package wirgen
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/phillip-england/wir/internal/wirdiag"
+	"github.com/phillip-england/wir/internal/wirparser"
+)
+
+// composeImports are the fully qualified names of everything the generated
+// code may reference.
+var composeImports = map[string]string{
+	"Button":        "androidx.compose.material3.Button",
+	"Column":        "androidx.compose.foundation.layout.Column",
+	"Composable":    "androidx.compose.runtime.Composable",
+	"LazyColumn":    "androidx.compose.foundation.lazy.LazyColumn",
+	"MaterialTheme": "androidx.compose.material3.MaterialTheme",
+	"Modifier":      "androidx.compose.ui.Modifier",
+	"Text":          "androidx.compose.material3.Text",
+	"items":         "androidx.compose.foundation.lazy.items",
+	"testTag":       "androidx.compose.ui.platform.testTag",
+}
+
+// TargetCompose generates a Jetpack Compose @Composable function. Like the
+// swiftui target it maps h1, p, button, ul, li and div. The id attribute
+// becomes a test tag and class, which only styles the web, is dropped; any
+// other attribute is reported. Imported components are expected in the same
+// package, so they are called without an import. Slots are nullable
+// composable lambdas following the props, with the default slot, content,
+// last so callers can pass it as a trailing lambda.
+type TargetCompose struct {
+	pkg string
+}
+
+func TargetComposeNew(opts TargetOptions) (Target, error) {
+	return &TargetCompose{
+		pkg: opts.Package,
+	}, nil
+}
+
+func (t *TargetCompose) Ext() string {
+	return ".kt"
+}
+
+func (t *TargetCompose) Generate(c *Component) (string, error) {
+	g := &composeGen{
+		lines: lines{
+			unit: "    ",
+		},
+		c:       c,
+		imports: map[string]bool{},
+	}
+	g.line("@" + g.use("Composable"))
+	if len(c.Props) == 0 && len(c.Slots) == 0 {
+		g.line("fun " + c.Name + "() {")
+	} else {
+		g.line("fun " + c.Name + "(")
+		g.indent++
+		for _, prop := range c.Props {
+			if prop.Default != nil {
+				g.line(prop.Name + ": " + kotlinType(prop.Type) + " = " + kotlinDefault(c, prop) + ",")
+				continue
+			}
+			g.line(prop.Name + ": " + kotlinType(prop.Type) + ",")
+		}
+		for _, s := range defaultSlotLast(c.Slots) {
+			g.line(slotName(s, "content") + ": (@" + g.use("Composable") + " () -> Unit)? = null,")
+		}
+		g.indent--
+		g.line(") {")
+	}
+	g.indent++
+	g.stack(c.Ast.Root.Children, Scope{})
+	g.indent--
+	g.line("}")
+	if len(g.diags) > 0 {
+		return "", g.diags
+	}
+
+	var sb strings.Builder
+	sb.WriteString(header(c, "//", "") + "\n")
+	if t.pkg != "" {
+		sb.WriteString("package " + t.pkg + "\n\n")
+	}
+	var imports []string
+	for imp := range g.imports {
+		imports = append(imports, "import "+imp)
+	}
+	sort.Strings(imports)
+	sb.WriteString(strings.Join(imports, "\n") + "\n\n")
+	for _, def := range c.Types {
+		if def.IsEnum() {
+			sb.WriteString("enum class " + def.Name + " {\n")
+			for _, v := range def.Values {
+				sb.WriteString("    " + kotlinEnumValue(v) + ",\n")
+			}
+			sb.WriteString("}\n\n")
+			continue
+		}
+		if len(def.Fields) == 0 {
+			sb.WriteString("class " + def.Name + "\n\n")
+			continue
+		}
+		sb.WriteString("data class " + def.Name + "(\n")
+		for _, f := range def.Fields {
+			sb.WriteString("    val " + f.Name + ": " + kotlinType(f.Type) + ",\n")
+		}
+		sb.WriteString(")\n\n")
+	}
+	sb.WriteString(g.out())
+	return sb.String(), nil
+}
+
+// kotlinDefault renders the default of prop. Kotlin won't take an integer
+// literal for a Double, so floats always have a decimal point.
+func kotlinDefault(c *Component, prop Field) string {
+	if c.IsEnum(prop.Type) {
+		return prop.Type + "." + kotlinEnumValue(prop.Default.(string))
+	}
+	out := literal(prop.Default, func(s string) string {
+		return "\"" + kotlinLiteral(s) + "\""
+	}, "emptyList()")
+	if prop.Type == "float" && !strings.ContainsAny(out, ".eE") {
+		out += ".0"
+	}
+	return out
+}
+
+func kotlinType(t string) string {
+	if IsList(t) {
+		return "List<" + kotlinType(ListElem(t)) + ">"
+	}
+	switch t {
+	default:
+		{
+			return t
+		}
+	case "string":
+		{
+			return "String"
+		}
+	case "int":
+		{
+			return "Int"
+		}
+	case "float":
+		{
+			return "Double"
+		}
+	case "bool":
+		{
+			return "Boolean"
+		}
+	}
+}
+
+type composeGen struct {
+	lines
+	c       *Component
+	imports map[string]bool
+	diags   wirdiag.List
+}
+
+// use imports name and returns how to refer to it. A component sharing a
+// name with a Compose function, such as Button, refers to it fully qualified,
+// as does a component using one.
+func (g *composeGen) use(name string) string {
+	if _, imported := g.c.Import(name); imported || name == g.c.Name {
+		return composeImports[name]
+	}
+	g.imports[composeImports[name]] = true
+	return name
+}
+
+// stack writes nodes as a single composable, wrapping several in a Column.
+func (g *composeGen) stack(nodes []*wirparser.AstNode, scope Scope) {
+	if len(nodes) <= 1 {
+		g.nodes(nodes, scope)
+		return
+	}
+	g.line(g.use("Column") + " {")
+	g.indent++
+	g.nodes(nodes, scope)
+	g.indent--
+	g.line("}")
+}
+
+func (g *composeGen) nodes(nodes []*wirparser.AstNode, scope Scope) {
+	for _, n := range nodes {
+		g.node(n, scope)
+	}
+}
+
+func (g *composeGen) node(n *wirparser.AstNode, scope Scope) {
+	switch n.Kind {
+	default:
+		{
+			g.nodes(n.Children, scope)
+		}
+	case wirparser.AstNodeKindString:
+		{
+			g.line(g.use("Text") + "(" + kotlinString(n.Children) + ")")
+		}
+	case wirparser.AstNodeKindForDirective:
+		{
+			g.line(g.use("LazyColumn") + " {")
+			g.indent++
+			g.items(n, scope)
+			g.indent--
+			g.line("}")
+		}
+	case wirparser.AstNodeKindIfDirective:
+		{
+			for i, branch := range n.Children {
+				switch {
+				default:
+					{
+						g.line("} else if (" + branch.Value + ") {")
+					}
+				case i == 0:
+					{
+						g.line("if (" + branch.Value + ") {")
+					}
+				case branch.Value == "":
+					{
+						g.line("} else {")
+					}
+				}
+				g.indent++
+				g.stack(branch.Children, scope)
+				g.indent--
+			}
+			g.line("}")
+		}
+	case wirparser.AstNodeKindSwitchDirective:
+		{
+			g.match(n, scope)
+		}
+	case wirparser.AstNodeKindElement:
+		{
+			g.element(n, scope)
+		}
+	case wirparser.AstNodeKindComponent:
+		{
+			u, _ := g.c.Import(n.TagName)
+			var args []string
+			for _, arg := range g.c.Args(n, false) {
+				value := kotlinDefault(u, Field{Type: arg.Prop.Type, Default: arg.Value})
+				if arg.Parts != nil {
+					value = kotlinString(arg.Parts)
+				}
+				if part, ok := singleInterpolation(arg.Parts); ok {
+					value = part.Value
+				}
+				args = append(args, arg.Prop.Name+" = "+value)
+			}
+			g.call(n, args, scope)
+		}
+	case wirparser.AstNodeKindSlotDirective:
+		{
+			name := slotName(Slot{Name: n.Value}, "content")
+			if len(n.Children) == 0 {
+				g.line(name + "?.invoke()")
+				return
+			}
+			g.line("if (" + name + " != null) {")
+			g.indent++
+			g.line(name + "()")
+			g.indent--
+			g.line("} else {")
+			g.indent++
+			g.stack(n.Children, scope)
+			g.indent--
+			g.line("}")
+		}
+	}
+}
+
+// call writes the call of the component n uses with args, passing content
+// for its named slots as lambda arguments and for its default slot as a
+// trailing lambda.
+func (g *composeGen) call(n *wirparser.AstNode, args []string, scope Scope) {
+	var named []Fill
+	var content []*wirparser.AstNode
+	for _, fill := range g.c.Fills(n) {
+		if fill.Slot.Name == "" {
+			content = fill.Children
+			continue
+		}
+		named = append(named, fill)
+	}
+	open := n.TagName
+	if len(named) > 0 {
+		g.line(n.TagName + "(")
+		g.indent++
+		for _, arg := range args {
+			g.line(arg + ",")
+		}
+		for _, fill := range named {
+			g.line(fill.Slot.Name + " = {")
+			g.indent++
+			g.stack(fill.Children, scope)
+			g.indent--
+			g.line("},")
+		}
+		g.indent--
+		open = ")"
+	} else if len(args) > 0 || content == nil {
+		open += "(" + strings.Join(args, ", ") + ")"
+	}
+	if content == nil {
+		g.line(open)
+		return
+	}
+	g.line(open + " {")
+	g.indent++
+	g.stack(content, scope)
+	g.indent--
+	g.line("}")
+}
+
+// match writes a @switch as a when statement, which must be exhaustive over
+// an enum.
+func (g *composeGen) match(n *wirparser.AstNode, scope Scope) {
+	t := InterpolationType(n)
+	isEnum := g.c.IsEnum(t)
+	g.line("when (" + n.Value + ") {")
+	g.indent++
+	for _, arm := range switchArms(n) {
+		switch {
+		default:
+			{
+				g.line(kotlinString([]*wirparser.AstNode{{Kind: wirparser.AstNodeKindText, Text: arm.Value}}) + " -> {")
+			}
+		case arm.Kind == wirparser.AstNodeKindSwitchDefault:
+			{
+				g.line("else -> {")
+			}
+		case isEnum:
+			{
+				g.line(t + "." + kotlinEnumValue(arm.Value) + " -> {")
+			}
+		}
+		g.indent++
+		g.stack(arm.Children, scope)
+		g.indent--
+		g.line("}")
+	}
+	if !coversSwitch(g.c, n) {
+		g.line("else -> {}")
+	}
+	g.indent--
+	g.line("}")
+}
+
+// kotlinEnumValue names the constant for a value of an enum class.
+func kotlinEnumValue(value string) string {
+	return strings.ToUpper(Snake(Camel(value)))
+}
+
+// items writes a @for as the items of a LazyColumn.
+func (g *composeGen) items(n *wirparser.AstNode, scope Scope) {
+	g.line(g.use("items") + "(" + ForList(n) + ") { " + n.Binding + " ->")
+	g.indent++
+	g.stack(n.Children, scope.With(n.Binding, n.BindingType))
+	g.indent--
+	g.line("}")
+}
+
+func (g *composeGen) element(n *wirparser.AstNode, scope Scope) {
+	modifier := g.modifier(n)
+	switch n.TagName {
+	default:
+		{
+			g.diags = append(g.diags, g.c.Diag(n.Span, "<%s> has no Compose mapping, expected one of h1, p, button, ul, li or div", n.TagName))
+		}
+	case "h1":
+		{
+			args := g.text(n) + ", style = " + g.use("MaterialTheme") + ".typography.headlineLarge"
+			if modifier != "" {
+				args += ", modifier = " + modifier
+			}
+			g.line(g.use("Text") + "(" + args + ")")
+		}
+	case "p":
+		{
+			args := g.text(n)
+			if modifier != "" {
+				args += ", modifier = " + modifier
+			}
+			g.line(g.use("Text") + "(" + args + ")")
+		}
+	case "button":
+		{
+			args := "onClick = {}"
+			if modifier != "" {
+				args += ", modifier = " + modifier
+			}
+			g.line(g.use("Button") + "(" + args + ") {")
+			g.indent++
+			if isTextOnly(n) {
+				g.line(g.use("Text") + "(" + g.text(n) + ")")
+			} else {
+				g.stack(n.Children, scope)
+			}
+			g.indent--
+			g.line("}")
+		}
+	case "ul":
+		{
+			open := g.use("LazyColumn")
+			if modifier != "" {
+				open += "(modifier = " + modifier + ")"
+			}
+			g.line(open + " {")
+			g.indent++
+			for _, child := range n.Children {
+				if child.Kind == wirparser.AstNodeKindForDirective {
+					g.items(child, scope)
+					continue
+				}
+				g.line("item {")
+				g.indent++
+				g.node(child, scope)
+				g.indent--
+				g.line("}")
+			}
+			g.indent--
+			g.line("}")
+		}
+	case "li", "div":
+		{
+			if modifier == "" {
+				g.stack(n.Children, scope)
+				return
+			}
+			g.line(g.use("Column") + "(modifier = " + modifier + ") {")
+			g.indent++
+			g.nodes(n.Children, scope)
+			g.indent--
+			g.line("}")
+		}
+	}
+}
+
+// modifier builds the Modifier for the attributes of n, reporting those
+// Compose has no equivalent for.
+func (g *composeGen) modifier(n *wirparser.AstNode) string {
+	out := ""
+	for _, attr := range n.Attrs {
+		switch attr.Key {
+		default:
+			{
+				g.diags = append(g.diags, g.c.Diag(attr.Span, "attribute %s on <%s> has no Compose mapping, only id and class are supported", attr.Key, n.TagName))
+			}
+		case "class":
+			{
+			}
+		case "id":
+			{
+				g.use("testTag")
+				out = g.use("Modifier") + ".testTag(" + kotlinString(attr.Parts) + ")"
+			}
+		}
+	}
+	return out
+}
+
+func (g *composeGen) text(n *wirparser.AstNode) string {
+	var parts []*wirparser.AstNode
+	for _, child := range n.Children {
+		if child.Kind != wirparser.AstNodeKindString {
+			g.diags = append(g.diags, g.c.Diag(child.Span, "<%s> can only contain text in the compose target", n.TagName))
+			continue
+		}
+		parts = append(parts, child.Children...)
+	}
+	return kotlinString(parts)
+}
+
+// kotlinString renders parts as a Kotlin string template.
+func kotlinString(parts []*wirparser.AstNode) string {
+	return "\"" + joinParts(parts, kotlinLiteral, func(n *wirparser.AstNode) string {
+		return "${" + n.Value + "}"
+	}) + "\""
+}
+
+// kotlinLiteral escapes s for use inside a Kotlin string literal.
+func kotlinLiteral(s string) string {
+	return strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "$", "\\$", "\n", "\\n").Replace(s)
+}
@@ -0,0 +1,420 @@
+package wirgen
+
+import (
+	"strings"
+
+	"github.com/phillip-england/wir/internal/wirdiag"
+	"github.com/phillip-england/wir/internal/wirparser"
+)
+
+// TargetFlutter generates a Flutter StatelessWidget. It maps the same
+// elements as the swiftui target and leaves out html attributes. Slots are
+// optional Widget fields, where child is the default slot.
+type TargetFlutter struct{}
+
+func TargetFlutterNew(opts TargetOptions) (Target, error) {
+	return &TargetFlutter{}, nil
+}
+
+func (t *TargetFlutter) Ext() string {
+	return ".dart"
+}
+
+func (t *TargetFlutter) Generate(c *Component) (string, error) {
+	g := &flutterGen{
+		lines: lines{
+			unit: "  ",
+		},
+		c: c,
+	}
+	g.line(header(c, "//", ""))
+	g.line("import 'package:flutter/material.dart';")
+	for _, u := range c.Imports {
+		g.line("import '" + strings.TrimPrefix(importPath(c, u, ".dart"), "./") + "';")
+	}
+	g.line("")
+	for _, def := range c.Types {
+		if def.IsEnum() {
+			var values []string
+			for _, v := range def.Values {
+				values = append(values, Camel(v))
+			}
+			g.line("enum " + def.Name + " { " + strings.Join(values, ", ") + " }")
+			g.line("")
+			continue
+		}
+		g.class(def.Name, "", def.Fields)
+		g.indent--
+		g.line("}")
+		g.line("")
+	}
+	fields := c.Props
+	for _, s := range defaultSlotLast(c.Slots) {
+		fields = append(fields, Field{
+			Name: slotName(s, "child"),
+			Type: "Widget?",
+		})
+	}
+	g.class(c.Name, "StatelessWidget", fields)
+	g.line("")
+	g.line("@override")
+	g.line("Widget build(BuildContext context) {")
+	g.indent++
+	g.stack(c.Ast.Root.Children, Scope{}, "return ", ";")
+	g.indent--
+	g.line("}")
+	g.indent--
+	g.line("}")
+	if len(g.diags) > 0 {
+		return "", g.diags
+	}
+	return g.out(), nil
+}
+
+func dartType(t string) string {
+	if IsList(t) {
+		return "List<" + dartType(ListElem(t)) + ">"
+	}
+	switch t {
+	default:
+		{
+			return t
+		}
+	case "string":
+		{
+			return "String"
+		}
+	case "float":
+		{
+			return "double"
+		}
+	}
+}
+
+type flutterGen struct {
+	lines
+	c     *Component
+	diags wirdiag.List
+}
+
+// class opens a class with a const constructor taking every field as a named
+// parameter, required unless it has a default or is nullable, leaving the
+// body open for the caller.
+func (g *flutterGen) class(name string, extends string, fields []Field) {
+	var params []string
+	if extends != "" {
+		g.line("class " + name + " extends " + extends + " {")
+		params = append(params, "super.key")
+	} else {
+		g.line("class " + name + " {")
+	}
+	g.indent++
+	for _, f := range fields {
+		if f.Default != nil {
+			params = append(params, "this."+f.Name+" = "+dartDefault(g.c, f))
+			continue
+		}
+		if strings.HasSuffix(f.Type, "?") {
+			params = append(params, "this."+f.Name)
+			continue
+		}
+		params = append(params, "required this."+f.Name)
+	}
+	switch len(params) {
+	default:
+		{
+			g.line("const " + name + "({")
+			g.indent++
+			for _, p := range params {
+				g.line(p + ",")
+			}
+			g.indent--
+			g.line("});")
+		}
+	case 0:
+		{
+			g.line("const " + name + "();")
+		}
+	case 1:
+		{
+			g.line("const " + name + "({" + params[0] + "});")
+		}
+	}
+	if len(fields) > 0 {
+		g.line("")
+	}
+	for _, f := range fields {
+		g.line("final " + dartType(f.Type) + " " + f.Name + ";")
+	}
+}
+
+// dartDefault renders the default of prop, which must be constant.
+func dartDefault(c *Component, prop Field) string {
+	if c.IsEnum(prop.Type) {
+		return prop.Type + "." + Camel(prop.Default.(string))
+	}
+	return literal(prop.Default, func(s string) string {
+		return "'" + dartLiteral(s) + "'"
+	}, "const []")
+}
+
+// stack writes nodes as a single widget expression between prefix and suffix,
+// wrapping several in a Column.
+func (g *flutterGen) stack(nodes []*wirparser.AstNode, scope Scope, prefix string, suffix string) {
+	if len(nodes) == 1 && nodes[0].Kind != wirparser.AstNodeKindForDirective && nodes[0].Kind != wirparser.AstNodeKindIfDirective {
+		g.widget(nodes[0], scope, prefix, suffix)
+		return
+	}
+	g.container("Column", "crossAxisAlignment: CrossAxisAlignment.start", nodes, scope, prefix, suffix)
+}
+
+func (g *flutterGen) container(name string, args string, nodes []*wirparser.AstNode, scope Scope, prefix string, suffix string) {
+	if len(nodes) == 0 {
+		g.line(prefix + "const SizedBox.shrink()" + suffix)
+		return
+	}
+	g.line(prefix + name + "(")
+	g.indent++
+	if args != "" {
+		g.line(args + ",")
+	}
+	g.line("children: [")
+	g.indent++
+	for _, n := range nodes {
+		if n.Kind == wirparser.AstNodeKindForDirective {
+			inner := scope.With(n.Binding, n.BindingType)
+			g.stack(n.Children, inner, "..."+ForList(n)+".map(("+n.Binding+") => ", "),")
+			continue
+		}
+		if n.Kind == wirparser.AstNodeKindIfDirective {
+			g.collectionIf(n, scope)
+			continue
+		}
+		g.widget(n, scope, "", ",")
+	}
+	g.indent--
+	g.line("],")
+	g.indent--
+	g.line(")" + suffix)
+}
+
+// collectionIf writes an @if as a collection if within a list of children,
+// with each branch a single widget.
+func (g *flutterGen) collectionIf(n *wirparser.AstNode, scope Scope) {
+	for i, branch := range n.Children {
+		switch {
+		default:
+			{
+				g.line("else if (" + branch.Value + ")")
+			}
+		case i == 0:
+			{
+				g.line("if (" + branch.Value + ")")
+			}
+		case branch.Value == "":
+			{
+				g.line("else")
+			}
+		}
+		suffix := ""
+		if i == len(n.Children)-1 {
+			suffix = ","
+		}
+		g.indent++
+		g.stack(branch.Children, scope, "", suffix)
+		g.indent--
+	}
+}
+
+func (g *flutterGen) widget(n *wirparser.AstNode, scope Scope, prefix string, suffix string) {
+	switch n.Kind {
+	default:
+		{
+			g.stack(n.Children, scope, prefix, suffix)
+		}
+	case wirparser.AstNodeKindString:
+		{
+			g.line(prefix + dartText(n.Children, "") + suffix)
+		}
+	case wirparser.AstNodeKindSwitchDirective:
+		{
+			g.match(n, scope, prefix, suffix)
+		}
+	case wirparser.AstNodeKindElement:
+		{
+			g.element(n, scope, prefix, suffix)
+		}
+	case wirparser.AstNodeKindComponent:
+		{
+			g.use(n, scope, prefix, suffix)
+		}
+	case wirparser.AstNodeKindSlotDirective:
+		{
+			name := slotName(Slot{Name: n.Value}, "child")
+			if len(n.Children) == 0 {
+				g.line(prefix + name + " ?? const SizedBox.shrink()" + suffix)
+				return
+			}
+			g.stack(n.Children, scope, prefix+name+" ?? ", suffix)
+		}
+	}
+}
+
+// use renders the widget of an imported component, which is const when every
+// prop passed is and it isn't given any content. Content for each slot is
+// passed as a widget argument of its own.
+func (g *flutterGen) use(n *wirparser.AstNode, scope Scope, prefix string, suffix string) {
+	u, _ := g.c.Import(n.TagName)
+	isConst := true
+	var args []string
+	for _, arg := range g.c.Args(n, false) {
+		value := dartDefault(u, Field{Type: arg.Prop.Type, Default: arg.Value})
+		if arg.Parts != nil {
+			isConst = false
+			value, _ = dartString(arg.Parts)
+		}
+		if part, ok := singleInterpolation(arg.Parts); ok {
+			value = part.Value
+		}
+		args = append(args, arg.Prop.Name+": "+value)
+	}
+	fills := g.c.Fills(n)
+	if len(fills) == 0 {
+		out := n.TagName + "(" + strings.Join(args, ", ") + ")"
+		if isConst {
+			out = "const " + out
+		}
+		g.line(prefix + out + suffix)
+		return
+	}
+	g.line(prefix + n.TagName + "(")
+	g.indent++
+	for _, arg := range args {
+		g.line(arg + ",")
+	}
+	for _, s := range defaultSlotLast(u.Slots) {
+		if fill, filled := findFill(fills, s); filled {
+			g.stack(fill.Children, scope, slotName(s, "child")+": ", ",")
+		}
+	}
+	g.indent--
+	g.line(")" + suffix)
+}
+
+// match writes a @switch as a switch expression, which must be exhaustive, so
+// one that leaves values unhandled falls back to an empty box.
+func (g *flutterGen) match(n *wirparser.AstNode, scope Scope, prefix string, suffix string) {
+	t := InterpolationType(n)
+	isEnum := g.c.IsEnum(t)
+	g.line(prefix + "switch (" + n.Value + ") {")
+	g.indent++
+	for _, arm := range switchArms(n) {
+		pattern := "_"
+		if arm.Kind == wirparser.AstNodeKindSwitchCase {
+			pattern, _ = dartString([]*wirparser.AstNode{{Kind: wirparser.AstNodeKindText, Text: arm.Value}})
+		}
+		if arm.Kind == wirparser.AstNodeKindSwitchCase && isEnum {
+			pattern = t + "." + Camel(arm.Value)
+		}
+		g.stack(arm.Children, scope, pattern+" => ", ",")
+	}
+	if !coversSwitch(g.c, n) {
+		g.line("_ => const SizedBox.shrink(),")
+	}
+	g.indent--
+	g.line("}" + suffix)
+}
+
+func (g *flutterGen) element(n *wirparser.AstNode, scope Scope, prefix string, suffix string) {
+	switch n.TagName {
+	default:
+		{
+			g.diags = append(g.diags, g.c.Diag(n.Span, "<%s> has no Flutter mapping, expected one of h1, p, button, ul, li or div", n.TagName))
+		}
+	case "h1":
+		{
+			g.line(prefix + dartText(g.text(n), "style: Theme.of(context).textTheme.headlineLarge") + suffix)
+		}
+	case "p":
+		{
+			g.line(prefix + dartText(g.text(n), "") + suffix)
+		}
+	case "button":
+		{
+			g.line(prefix + "ElevatedButton(")
+			g.indent++
+			g.line("onPressed: () {},")
+			if isTextOnly(n) {
+				g.line("child: " + dartText(g.text(n), "") + ",")
+			} else {
+				g.stack(n.Children, scope, "child: ", ",")
+			}
+			g.indent--
+			g.line(")" + suffix)
+		}
+	case "ul":
+		{
+			// a list inside a Column, including one a caller places this
+			// component in, has unbounded height unless it is shrink wrapped
+			g.container("ListView", "shrinkWrap: true", n.Children, scope, prefix, suffix)
+		}
+	case "li", "div":
+		{
+			g.stack(n.Children, scope, prefix, suffix)
+		}
+	}
+}
+
+func (g *flutterGen) text(n *wirparser.AstNode) []*wirparser.AstNode {
+	var parts []*wirparser.AstNode
+	for _, child := range n.Children {
+		if child.Kind != wirparser.AstNodeKindString {
+			g.diags = append(g.diags, g.c.Diag(child.Span, "<%s> can only contain text in the flutter target", n.TagName))
+			continue
+		}
+		parts = append(parts, child.Children...)
+	}
+	return parts
+}
+
+// dartText renders a Text widget, which is const when it has no
+// interpolations or extra arguments.
+func dartText(parts []*wirparser.AstNode, args string) string {
+	s, isConst := dartString(parts)
+	if args != "" {
+		return "Text(" + s + ", " + args + ")"
+	}
+	if isConst {
+		return "const Text(" + s + ")"
+	}
+	return "Text(" + s + ")"
+}
+
+// dartString renders parts as a single quoted Dart string, using $name where
+// the braces can be left out. It also reports whether the string is constant.
+func dartString(parts []*wirparser.AstNode) (string, bool) {
+	out := ""
+	isConst := true
+	for i, part := range parts {
+		if part.Kind != wirparser.AstNodeKindInterpolation {
+			out += dartLiteral(part.Text)
+			continue
+		}
+		isConst = false
+		next := ""
+		if i+1 < len(parts) {
+			next = parts[i+1].Text
+		}
+		if isIdent(part.Value) && (next == "" || !isIdent("a"+next[:1])) {
+			out += "$" + part.Value
+			continue
+		}
+		out += "${" + part.Value + "}"
+	}
+	return "'" + out + "'", isConst
+}
+
+// dartLiteral escapes s for use inside a single quoted Dart string.
+func dartLiteral(s string) string {
+	return strings.NewReplacer("\\", "\\\\", "'", "\\'", "$", "\\$", "\n", "\\n").Replace(s)
+}
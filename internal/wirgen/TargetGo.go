@@ -0,0 +1,477 @@
+package wirgen
+
+import (
+	"go/format"
+	"html"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/phillip-england/wir/internal/wherr"
+	"github.com/phillip-england/wir/internal/wirdiag"
+	"github.com/phillip-england/wir/internal/wirparser"
+)
+
+var goKeywords = []string{"break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough", "for", "func", "go", "goto", "if", "import", "interface", "map", "package", "range", "return", "select", "struct", "switch", "type", "var"}
+
+// goReserved are names the generated Render function already uses.
+var goReserved = []string{"w", "p", "err", "html", "io", "strconv"}
+
+// TargetGo generates a Go package per component holding a Props struct and a
+// Render function that streams escaped HTML. The output only imports the
+// standard library so it has no dependency on wir at runtime. A component
+// using another calls the Render function of its package, passing the
+// defaults of props it leaves out. Slots are Props fields holding a function
+// that writes their content, where Children is the default slot, and are
+// left nil when not filled.
+type TargetGo struct {
+	pkg    string
+	module string
+}
+
+func TargetGoNew(opts TargetOptions) (Target, error) {
+	if opts.Package != "" && !isIdent(opts.Package) {
+		return nil, wherr.Err(wherr.Here(), "invalid Go package name %s", opts.Package)
+	}
+	return &TargetGo{
+		pkg:    opts.Package,
+		module: opts.Module,
+	}, nil
+}
+
+func (t *TargetGo) Ext() string {
+	return ".go"
+}
+
+// Dir places each component in its own package directory since every one
+// declares its own Props and Render. The package a single file is built
+// into can be named instead with --package.
+func (t *TargetGo) Dir(c *Component) string {
+	return t.Package(c)
+}
+
+func (t *TargetGo) Package(c *Component) string {
+	if t.pkg != "" {
+		return t.pkg
+	}
+	return strings.ToLower(c.Name)
+}
+
+func (t *TargetGo) Generate(c *Component) (string, error) {
+	_, exists := c.Type("Props")
+	if exists {
+		return "", wirdiag.List{c.Diag(c.Ast.Root.Span, "the type name Props is reserved by the go target")}
+	}
+	if diags := goCheckImports(c, t.module, t.pkg); len(diags) > 0 {
+		return "", diags
+	}
+	g := &goGen{
+		c:       c,
+		imports: map[string]bool{"io": true},
+	}
+	for _, u := range c.Imports {
+		g.imports[goImportPath(t.module, u, t.Package(u))] = true
+	}
+	g.line("func Render(w io.Writer, p Props) error {")
+	for _, child := range c.Ast.Root.Children {
+		g.node(child, Scope{}, map[string]string{})
+	}
+	g.flush()
+	g.line("return nil")
+	g.line("}")
+
+	var sb strings.Builder
+	sb.WriteString(header(c, "//", "") + "\n")
+	sb.WriteString("package " + t.Package(c) + "\n\n")
+	var imports []string
+	for imp := range g.imports {
+		imports = append(imports, strconv.Quote(imp))
+	}
+	sort.Strings(imports)
+	sb.WriteString("import (\n" + strings.Join(imports, "\n") + "\n)\n\n")
+	for _, def := range c.Types {
+		if def.IsEnum() {
+			sb.WriteString(goEnum(def))
+			continue
+		}
+		sb.WriteString(goStruct(def.Name, def.Fields))
+	}
+	fields := c.Props
+	for _, s := range c.Slots {
+		fields = append(fields, Field{
+			Name: slotName(s, "children"),
+			Type: "func(w io.Writer) error",
+		})
+	}
+	sb.WriteString(goStruct("Props", fields))
+	if c.HasDefaults() {
+		sb.WriteString(goDefaultProps(c))
+	}
+	sb.WriteString(g.body.String())
+	out, err := format.Source([]byte(sb.String()))
+	if err != nil {
+		return "", wherr.Consume(wherr.Here(), err, "")
+	}
+	return string(out), nil
+}
+
+// goCheckImports reports why the packages of the components c imports can't
+// be imported, which needs the module path and a package per component.
+func goCheckImports(c *Component, module string, pkg string) wirdiag.List {
+	if len(c.Imports) == 0 {
+		return nil
+	}
+	imp := c.Ast.Imports[0]
+	if pkg != "" {
+		return wirdiag.List{c.Diag(imp.Span, "a component using @import needs a package of its own, build it without --package")}
+	}
+	if module == "" {
+		return wirdiag.List{c.Diag(imp.Span, "@import('%s') needs the Go module path the output is written under, set it with --module", imp.Value)}
+	}
+	return nil
+}
+
+// goImportPath is the import path of the package generated for u, which a
+// directory build writes under the directory u has in the project.
+func goImportPath(module string, u *Component, pkg string) string {
+	return path.Join(module, path.Dir(u.RelPath), pkg)
+}
+
+// goArg renders the value passed to a prop in Go, reading values with raw and
+// converting them to strings with str when text and values are mixed.
+func goArg(arg Arg, raw func(n *wirparser.AstNode) string, str func(n *wirparser.AstNode) string) string {
+	if arg.Parts == nil {
+		return literal(arg.Value, strconv.Quote, "nil")
+	}
+	if n, ok := singleInterpolation(arg.Parts); ok {
+		return raw(n)
+	}
+	var terms []string
+	for _, part := range arg.Parts {
+		if part.Kind == wirparser.AstNodeKindInterpolation {
+			terms = append(terms, str(part))
+			continue
+		}
+		terms = append(terms, strconv.Quote(part.Text))
+	}
+	return strings.Join(terms, " + ")
+}
+
+func goStruct(name string, fields []Field) string {
+	s := "type " + name + " struct {\n"
+	for _, f := range fields {
+		s += Pascal(f.Name) + " " + goType(f.Type) + "\n"
+	}
+	return s + "}\n\n"
+}
+
+// goDefaultProps declares a DefaultProps function returning Props filled in
+// with the defaults from @props, since Go has no default arguments.
+func goDefaultProps(c *Component) string {
+	s := "// DefaultProps returns Props holding the defaults declared by @props.\n"
+	s += "func DefaultProps() Props {\nreturn Props{\n"
+	for _, prop := range c.Props {
+		if prop.Default == nil {
+			continue
+		}
+		s += Pascal(prop.Name) + ": " + goLiteral(c, prop) + ",\n"
+	}
+	return s + "}\n}\n\n"
+}
+
+// goLiteral renders the default of prop in Go.
+func goLiteral(c *Component, prop Field) string {
+	if c.IsEnum(prop.Type) {
+		return goEnumValue(prop.Type, prop.Default.(string))
+	}
+	return literal(prop.Default, strconv.Quote, goType(prop.Type)+"{}")
+}
+
+// goEnum declares an enum as a string type with a constant for each value.
+func goEnum(def *TypeDef) string {
+	width := 0
+	for _, v := range def.Values {
+		width = max(width, len(goEnumValue(def.Name, v)))
+	}
+	s := "type " + def.Name + " string\n\nconst (\n"
+	for _, v := range def.Values {
+		name := goEnumValue(def.Name, v)
+		s += "\t" + name + strings.Repeat(" ", width-len(name)) + " " + def.Name + " = " + strconv.Quote(v) + "\n"
+	}
+	return s + ")\n\n"
+}
+
+func goEnumValue(enum string, value string) string {
+	return enum + Pascal(value)
+}
+
+// goCase writes the literal of a @case in the @switch n, naming the constant
+// when n switches on an enum.
+func goCase(c *Component, n *wirparser.AstNode, lit string) string {
+	t := InterpolationType(n)
+	if c.IsEnum(t) {
+		return goEnumValue(t, lit)
+	}
+	return strconv.Quote(lit)
+}
+
+func goType(t string) string {
+	if IsList(t) {
+		return "[]" + goType(ListElem(t))
+	}
+	if t == "float" {
+		return "float64"
+	}
+	return t
+}
+
+type goGen struct {
+	c       *Component
+	imports map[string]bool
+	body    strings.Builder
+	static  string
+}
+
+func (g *goGen) line(s string) {
+	g.body.WriteString(s + "\n")
+}
+
+// write buffers static html so neighbouring text is written in one call.
+func (g *goGen) write(s string) {
+	g.static += s
+}
+
+func (g *goGen) flush() {
+	if g.static == "" {
+		return
+	}
+	g.emit(strconv.Quote(g.static))
+	g.static = ""
+}
+
+func (g *goGen) emit(expr string) {
+	g.line("if _, err := io.WriteString(w, " + expr + "); err != nil {")
+	g.line("return err")
+	g.line("}")
+}
+
+// node generates the statements for n. names maps @for bindings to the Go
+// identifiers used for them.
+func (g *goGen) node(n *wirparser.AstNode, scope Scope, names map[string]string) {
+	switch n.Kind {
+	default:
+		{
+			for _, child := range n.Children {
+				g.node(child, scope, names)
+			}
+		}
+	case wirparser.AstNodeKindElement:
+		{
+			g.write("<" + n.TagName)
+			for _, attr := range n.Attrs {
+				g.write(" " + attr.Key)
+				if attr.IsBool() {
+					continue
+				}
+				g.write("=\"")
+				for _, part := range attr.Parts {
+					g.node(part, scope, names)
+				}
+				g.write("\"")
+			}
+			g.write(">")
+			if isVoidElement(n.TagName) {
+				return
+			}
+			for _, child := range n.Children {
+				g.node(child, scope, names)
+			}
+			g.write("</" + n.TagName + ">")
+		}
+	case wirparser.AstNodeKindText:
+		{
+			g.write(html.EscapeString(n.Text))
+		}
+	case wirparser.AstNodeKindInterpolation:
+		{
+			g.flush()
+			g.imports["html"] = true
+			g.emit("html.EscapeString(" + g.format(n.Value, scope, names) + ")")
+		}
+	case wirparser.AstNodeKindForDirective:
+		{
+			g.flush()
+			name := goLocal(n.Binding)
+			list := g.expr(ForList(n), names)
+			if usesBinding(n) {
+				g.line("for _, " + name + " := range " + list + " {")
+			} else {
+				g.line("for range " + list + " {")
+			}
+			inner := map[string]string{}
+			for k, v := range names {
+				inner[k] = v
+			}
+			inner[n.Binding] = name
+			for _, child := range n.Children {
+				g.node(child, scope.With(n.Binding, n.BindingType), inner)
+			}
+			g.flush()
+			g.line("}")
+		}
+	case wirparser.AstNodeKindIfDirective:
+		{
+			g.flush()
+			for i, branch := range n.Children {
+				switch {
+				default:
+					{
+						g.line("} else if " + g.expr(branch.Value, names) + " {")
+					}
+				case i == 0:
+					{
+						g.line("if " + g.expr(branch.Value, names) + " {")
+					}
+				case branch.Value == "":
+					{
+						g.line("} else {")
+					}
+				}
+				for _, child := range branch.Children {
+					g.node(child, scope, names)
+				}
+				g.flush()
+			}
+			g.line("}")
+		}
+	case wirparser.AstNodeKindComponent:
+		{
+			g.flush()
+			pkg := strings.ToLower(n.TagName)
+			g.line("if err := " + pkg + ".Render(w, " + pkg + ".Props{")
+			for _, arg := range g.c.Args(n, true) {
+				g.line(Pascal(arg.Prop.Name) + ": " + goArg(arg, func(part *wirparser.AstNode) string {
+					return g.expr(part.Value, names)
+				}, func(part *wirparser.AstNode) string {
+					return g.format(part.Value, scope, names)
+				}) + ",")
+			}
+			for _, fill := range g.c.Fills(n) {
+				g.line(Pascal(slotName(fill.Slot, "children")) + ": func(w io.Writer) error {")
+				for _, child := range fill.Children {
+					g.node(child, scope, names)
+				}
+				g.flush()
+				g.line("return nil")
+				g.line("},")
+			}
+			g.line("}); err != nil {")
+			g.line("return err")
+			g.line("}")
+		}
+	case wirparser.AstNodeKindSlotDirective:
+		{
+			g.flush()
+			field := "p." + Pascal(slotName(Slot{Name: n.Value}, "children"))
+			g.line("if " + field + " != nil {")
+			g.line("if err := " + field + "(w); err != nil {")
+			g.line("return err")
+			g.line("}")
+			if len(n.Children) > 0 {
+				g.line("} else {")
+				for _, child := range n.Children {
+					g.node(child, scope, names)
+				}
+				g.flush()
+			}
+			g.line("}")
+		}
+	case wirparser.AstNodeKindSwitchDirective:
+		{
+			g.flush()
+			g.line("switch " + g.expr(n.Value, names) + " {")
+			for _, arm := range switchArms(n) {
+				if arm.Kind == wirparser.AstNodeKindSwitchDefault {
+					g.line("default:")
+				} else {
+					g.line("case " + goCase(g.c, n, arm.Value) + ":")
+				}
+				for _, child := range arm.Children {
+					g.node(child, scope, names)
+				}
+				g.flush()
+			}
+			g.line("}")
+		}
+	}
+}
+
+// expr translates a wir expression such as user.name into Go.
+func (g *goGen) expr(value string, names map[string]string) string {
+	parts := strings.Split(value, ".")
+	out, bound := names[parts[0]]
+	if !bound {
+		out = "p." + Pascal(parts[0])
+	}
+	for _, part := range parts[1:] {
+		out += "." + Pascal(part)
+	}
+	return out
+}
+
+// format converts an expression to a string according to its type.
+func (g *goGen) format(value string, scope Scope, names map[string]string) string {
+	expr := g.expr(value, names)
+	switch g.c.ExprType(value, scope) {
+	default:
+		{
+			return expr
+		}
+	case "int":
+		{
+			g.imports["strconv"] = true
+			return "strconv.Itoa(" + expr + ")"
+		}
+	case "float":
+		{
+			g.imports["strconv"] = true
+			return "strconv.FormatFloat(" + expr + ", 'f', -1, 64)"
+		}
+	case "bool":
+		{
+			g.imports["strconv"] = true
+			return "strconv.FormatBool(" + expr + ")"
+		}
+	}
+}
+
+// goLocal picks an identifier for a loop binding that does not clash with a
+// keyword or a name Render already uses.
+func goLocal(name string) string {
+	for _, k := range append(goKeywords, goReserved...) {
+		if k == name {
+			return name + "Item"
+		}
+	}
+	return name
+}
+
+// usesBinding reports whether the body of a @for reads its binding, since Go
+// rejects unused loop variables.
+func usesBinding(n *wirparser.AstNode) bool {
+	used := false
+	for _, child := range n.Children {
+		child.Walk(func(node *wirparser.AstNode) bool {
+			if node.Kind == wirparser.AstNodeKindForDirective && node.Binding == n.Binding {
+				return false
+			}
+			isExpr := node.Kind == wirparser.AstNodeKindInterpolation || node.Kind == wirparser.AstNodeKindIfBranch || node.Kind == wirparser.AstNodeKindSwitchDirective
+			if isExpr && strings.Split(node.Value, ".")[0] == n.Binding {
+				used = true
+			}
+			return !used
+		})
+	}
+	return used
+}
@@ -0,0 +1,321 @@
+package wirgen
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/phillip-england/wir/internal/wirdiag"
+	"github.com/phillip-england/wir/internal/wirparser"
+)
+
+// TargetHandlebars generates a logic-less template. Inside a loop the context
+// is the item, so user.name is written as name. Handlebars reaches the outer
+// context with ../ while mustache searches up the context stack, which fails
+// when an inner item has a field of the same name; mustache output reports
+// those references instead of rendering the wrong value. A @switch compares
+// values with an eq helper the application registers, so it can't be used
+// with mustache at all. Imported components are rendered as partials, which
+// handlebars passes every prop to, defaults too, since a partial also sees the
+// context it is rendered in. Slot content is passed in a partial block as an
+// inline partial per slot, the default one too since @partial-block is also
+// set by fills alone, and rendered in the context of the partial. Mustache
+// partials can't be passed anything.
+type TargetHandlebars struct {
+	isMustache bool
+}
+
+func TargetHandlebarsNew(opts TargetOptions) (Target, error) {
+	return &TargetHandlebars{}, nil
+}
+
+func TargetMustacheNew(opts TargetOptions) (Target, error) {
+	return &TargetHandlebars{
+		isMustache: true,
+	}, nil
+}
+
+func (t *TargetHandlebars) Ext() string {
+	if t.isMustache {
+		return ".mustache"
+	}
+	return ".hbs"
+}
+
+func (t *TargetHandlebars) Generate(c *Component) (string, error) {
+	d := &handlebarsDialect{
+		c:          c,
+		isMustache: t.isMustache,
+	}
+	p := markupPrinterNew(d, 0)
+	p.nodes(c.Ast.Root.Children, Scope{})
+	if len(d.diags) > 0 {
+		return "", d.diags
+	}
+	var sb strings.Builder
+	if t.isMustache {
+		sb.WriteString(header(c, "{{!", " }}"))
+		sb.WriteString(contextComment(c, "{{!", "}}"))
+	} else {
+		sb.WriteString(header(c, "{{!--", " --}}"))
+		sb.WriteString(contextComment(c, "{{!--", "--}}"))
+	}
+	sb.WriteString(p.out())
+	return sb.String(), nil
+}
+
+type handlebarsDialect struct {
+	c          *Component
+	isMustache bool
+	loops      []*wirparser.AstNode
+	diags      wirdiag.List
+	// use is the component whose slot content is being rendered, inside
+	// useLoops of the loops.
+	use      *wirparser.AstNode
+	useLoops int
+}
+
+// path resolves a wir expression against the loops it is nested in.
+func (d *handlebarsDialect) path(n *wirparser.AstNode) string {
+	root, member, hasMember := strings.Cut(n.Value, ".")
+	owner := 0
+	for i := len(d.loops) - 1; i >= 0; i-- {
+		if d.loops[i].Binding == root {
+			owner = i + 1
+			break
+		}
+	}
+	if d.use != nil && owner <= d.useLoops {
+		u, _ := d.c.Import(d.use.TagName)
+		_, shadowed := u.Prop(root)
+		if d.useLoops > 0 {
+			d.diags = append(d.diags, d.c.Diag(n.Span, "handlebars renders content passed to %s inside the partial, so it can't use %s from inside the @for around it", d.use.TagName, n.Value))
+		} else if shadowed {
+			d.diags = append(d.diags, d.c.Diag(n.Span, "handlebars renders content passed to %s inside the partial, where %s is its prop of the same name", d.use.TagName, root))
+		}
+	}
+	out := n.Value
+	if owner > 0 {
+		out = member
+	}
+	up := len(d.loops) - owner
+	if !d.isMustache {
+		if owner > 0 && !hasMember {
+			return strings.Repeat("../", up) + "this"
+		}
+		return strings.Repeat("../", up) + out
+	}
+	if owner > 0 && !hasMember {
+		if up > 0 {
+			d.diags = append(d.diags, d.c.Diag(n.Span, "mustache can't refer to %s from inside the loop over %s", root, ForList(d.loops[len(d.loops)-1])))
+		}
+		return "."
+	}
+	first, _, _ := strings.Cut(out, ".")
+	for _, loop := range d.loops[owner:] {
+		def, ok := d.c.Type(loop.BindingType)
+		if !ok {
+			continue
+		}
+		for _, f := range def.Fields {
+			if f.Name == first {
+				d.diags = append(d.diags, d.c.Diag(n.Span, "mustache would read %s from %s.%s, rename the field or use the handlebars target", n.Value, loop.Binding, f.Name))
+			}
+		}
+	}
+	return out
+}
+
+// attr toggles an attribute made of a lone bool interpolation with a section
+// rather than setting it to "true" or "false".
+func (d *handlebarsDialect) attr(a wirparser.AstAttr, scope Scope) string {
+	if a.IsBool() {
+		return " " + a.Key
+	}
+	if n, ok := singleInterpolation(a.Parts); ok && d.c.ExprType(n.Value, scope) == "bool" {
+		path := d.path(n)
+		if d.isMustache {
+			return "{{#" + path + "}} " + a.Key + "{{/" + path + "}}"
+		}
+		return "{{#if " + path + "}} " + a.Key + "{{/if}}"
+	}
+	return " " + a.Key + "=\"" + joinParts(a.Parts, braceSafeText, func(n *wirparser.AstNode) string {
+		return "{{" + d.path(n) + "}}"
+	}) + "\""
+}
+
+func (d *handlebarsDialect) text(n *wirparser.AstNode, scope Scope) string {
+	return joinParts(n.Children, braceSafeText, func(part *wirparser.AstNode) string {
+		return "{{" + d.path(part) + "}}"
+	})
+}
+
+func (d *handlebarsDialect) loop(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	list := &wirparser.AstNode{
+		Value: ForList(n),
+		Span:  n.Span,
+	}
+	path := d.path(list)
+	if d.isMustache {
+		p.line("{{#" + path + "}}")
+	} else {
+		p.line("{{#each " + path + "}}")
+	}
+	d.loops = append(d.loops, n)
+	p.indent++
+	p.nodes(n.Children, scope.With(n.Binding, n.BindingType))
+	p.indent--
+	d.loops = d.loops[:len(d.loops)-1]
+	if d.isMustache {
+		p.line("{{/" + path + "}}")
+	} else {
+		p.line("{{/each}}")
+	}
+}
+
+// cond maps an @if chain onto Handlebars' else if. Mustache has no else, so
+// each later branch is nested in an inverted section of the one before it.
+func (d *handlebarsDialect) cond(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	if d.isMustache {
+		d.mustacheBranches(p, n.Children, scope)
+		return
+	}
+	d.chain(p, n, scope, d.path)
+}
+
+func (d *handlebarsDialect) match(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	if d.isMustache {
+		d.diags = append(d.diags, d.c.Diag(n.Span, "mustache can't compare values, use the handlebars target for @switch"))
+		return
+	}
+	value := d.path(n)
+	d.chain(p, switchAsIf(n, func(lit string) string {
+		return "(eq " + value + " " + strconv.Quote(lit) + ")"
+	}), scope, func(branch *wirparser.AstNode) string {
+		return branch.Value
+	})
+}
+
+// chain renders an @if chain with else if, writing each condition with test.
+func (d *handlebarsDialect) chain(p *markupPrinter, n *wirparser.AstNode, scope Scope, test func(branch *wirparser.AstNode) string) {
+	p.branches(n.Children, scope, func(i int, branch *wirparser.AstNode) string {
+		switch {
+		default:
+			{
+				return "{{else if " + test(branch) + "}}"
+			}
+		case i == 0:
+			{
+				return "{{#if " + test(branch) + "}}"
+			}
+		case branch.Value == "":
+			{
+				return "{{else}}"
+			}
+		}
+	}, "{{/if}}")
+}
+
+func (d *handlebarsDialect) mustacheBranches(p *markupPrinter, branches []*wirparser.AstNode, scope Scope) {
+	branch := branches[0]
+	if branch.Value == "" {
+		p.nodes(branch.Children, scope)
+		return
+	}
+	path := d.path(branch)
+	p.line("{{#" + path + "}}")
+	p.indent++
+	p.nodes(branch.Children, scope)
+	p.indent--
+	p.line("{{/" + path + "}}")
+	if len(branches) == 1 {
+		return
+	}
+	p.line("{{^" + path + "}}")
+	p.indent++
+	d.mustacheBranches(p, branches[1:], scope)
+	p.indent--
+	p.line("{{/" + path + "}}")
+}
+
+func (d *handlebarsDialect) component(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	u, _ := d.c.Import(n.TagName)
+	name := templateName(u, "")
+	partial := name
+	if d.isMustache {
+		if len(n.Attrs) > 0 {
+			d.diags = append(d.diags, d.c.Diag(n.Span, "mustache partials can't be passed props, use the handlebars target to pass them to %s", n.TagName))
+			return
+		}
+		if len(n.Children) > 0 {
+			d.diags = append(d.diags, d.c.Diag(n.Span, "mustache partials can't be passed content, use the handlebars target to fill the slots of %s", n.TagName))
+			return
+		}
+		p.line("{{> " + partial + "}}")
+		return
+	}
+	for _, arg := range d.c.Args(n, true) {
+		if arg.Parts == nil {
+			partial += " " + arg.Prop.Name + "=" + literal(arg.Value, strconv.Quote, "[]")
+			continue
+		}
+		part, ok := singleInterpolation(arg.Parts)
+		if !ok {
+			d.diags = append(d.diags, d.c.Diag(n.Span, "handlebars can't pass text mixed with values to %s of %s, pass a single value", arg.Prop.Name, n.TagName))
+			continue
+		}
+		partial += " " + arg.Prop.Name + "=" + d.path(part)
+	}
+	fills := d.c.Fills(n)
+	if len(fills) == 0 {
+		p.line("{{> " + partial + "}}")
+		return
+	}
+	prevUse, prevLoops := d.use, d.useLoops
+	d.use, d.useLoops = n, len(d.loops)
+	p.line("{{#> " + partial + "}}")
+	p.indent++
+	for _, fill := range fills {
+		p.line("{{#*inline \"" + slotPartial(u, fill.Slot) + "\"}}")
+		p.indent++
+		p.nodes(fill.Children, scope)
+		p.indent--
+		p.line("{{/inline}}")
+	}
+	p.indent--
+	p.line("{{/" + name + "}}")
+	d.use, d.useLoops = prevUse, prevLoops
+}
+
+// slot renders the inline partial of the slot, falling back to the children
+// of n when the slot wasn't filled.
+func (d *handlebarsDialect) slot(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	if d.isMustache {
+		d.diags = append(d.diags, d.c.Diag(n.Span, "mustache has no slots, use the handlebars target for @slot"))
+		return
+	}
+	if len(d.loops) > 0 {
+		d.diags = append(d.diags, d.c.Diag(n.Span, "handlebars renders slot content in the context it is placed in, so @slot can't be inside @for"))
+		return
+	}
+	name := slotPartial(d.c, Slot{Name: n.Value})
+	if len(n.Children) == 0 {
+		p.line("{{#> " + name + "}}{{/" + name + "}}")
+		return
+	}
+	p.line("{{#> " + name + "}}")
+	p.indent++
+	p.nodes(n.Children, scope)
+	p.indent--
+	p.line("{{/" + name + "}}")
+}
+
+// slotPartial names the inline partial holding the content for the slot s of
+// c, prefixed with the component so that it doesn't reach the slots of the
+// same name of components c renders.
+func slotPartial(c *Component, s Slot) string {
+	return wcAttrName(c.Name) + "-" + slotName(s, "children")
+}
+
+func (d *handlebarsDialect) voidEnd() string {
+	return ">"
+}
@@ -0,0 +1,398 @@
+package wirgen
+
+import (
+	"html"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/phillip-england/wir/internal/wirdiag"
+	"github.com/phillip-england/wir/internal/wirparser"
+)
+
+var voidElements = []string{"area", "base", "br", "col", "embed", "hr", "img", "input", "link", "meta", "source", "track", "wbr"}
+
+// TargetHtml renders a component straight to static HTML using values from a
+// props file. Props for a component are read from the object under its name
+// when the file has one, otherwise from the top level of the file. Props the
+// file leaves out take their @props default. Imported components are rendered
+// in place with the props they are passed, and their slots with the content
+// they are filled with or else their fallback.
+type TargetHtml struct {
+	props map[string]any
+}
+
+func TargetHtmlNew(opts TargetOptions) (Target, error) {
+	return &TargetHtml{
+		props: opts.Props,
+	}, nil
+}
+
+func (t *TargetHtml) Ext() string {
+	return ".html"
+}
+
+func (t *TargetHtml) Generate(c *Component) (string, error) {
+	props := t.props
+	if scoped, ok := t.props[c.Name].(map[string]any); ok {
+		props = scoped
+	}
+	if c.HasDefaults() {
+		withDefaults := map[string]any{}
+		for _, prop := range c.Props {
+			if prop.Default != nil {
+				withDefaults[prop.Name] = prop.Default
+			}
+		}
+		for k, v := range props {
+			withDefaults[k] = v
+		}
+		props = withDefaults
+	}
+	r := &htmlRenderer{
+		c:      c,
+		values: props,
+		types:  Scope{},
+	}
+	var sb strings.Builder
+	for _, child := range c.Ast.Root.Children {
+		r.render(&sb, child)
+	}
+	if len(r.diags) > 0 {
+		return "", r.diags
+	}
+	sb.WriteString("\n")
+	return sb.String(), nil
+}
+
+type htmlRenderer struct {
+	c      *Component
+	values map[string]any
+	types  Scope
+	diags  wirdiag.List
+	// fills renders the content passed to each slot by the component using
+	// c, with the renderer of that component.
+	fills map[string]func(sb *strings.Builder)
+}
+
+func (r *htmlRenderer) render(sb *strings.Builder, n *wirparser.AstNode) {
+	switch n.Kind {
+	default:
+		{
+			for _, child := range n.Children {
+				r.render(sb, child)
+			}
+		}
+	case wirparser.AstNodeKindElement:
+		{
+			sb.WriteString("<" + n.TagName)
+			for _, attr := range n.Attrs {
+				sb.WriteString(" " + attr.Key)
+				if attr.IsBool() {
+					continue
+				}
+				sb.WriteString("=\"")
+				for _, part := range attr.Parts {
+					r.render(sb, part)
+				}
+				sb.WriteString("\"")
+			}
+			sb.WriteString(">")
+			if isVoidElement(n.TagName) {
+				return
+			}
+			for _, child := range n.Children {
+				r.render(sb, child)
+			}
+			sb.WriteString("</" + n.TagName + ">")
+		}
+	case wirparser.AstNodeKindText:
+		{
+			sb.WriteString(html.EscapeString(n.Text))
+		}
+	case wirparser.AstNodeKindInterpolation:
+		{
+			val, ok := r.lookup(n)
+			if !ok {
+				return
+			}
+			sb.WriteString(html.EscapeString(val))
+		}
+	case wirparser.AstNodeKindForDirective:
+		{
+			r.renderFor(sb, n)
+		}
+	case wirparser.AstNodeKindIfDirective:
+		{
+			r.renderIf(sb, n)
+		}
+	case wirparser.AstNodeKindSwitchDirective:
+		{
+			r.renderSwitch(sb, n)
+		}
+	case wirparser.AstNodeKindComponent:
+		{
+			r.renderUse(sb, n)
+		}
+	case wirparser.AstNodeKindSlotDirective:
+		{
+			if fill, filled := r.fills[n.Value]; filled {
+				fill(sb)
+				return
+			}
+			for _, child := range n.Children {
+				r.render(sb, child)
+			}
+		}
+	}
+}
+
+// renderUse renders the component n uses with the values it passes, which are
+// resolved against the props of the component using it.
+func (r *htmlRenderer) renderUse(sb *strings.Builder, n *wirparser.AstNode) {
+	u, _ := r.c.Import(n.TagName)
+	values := map[string]any{}
+	for _, arg := range r.c.Args(n, true) {
+		if arg.Parts == nil {
+			values[arg.Prop.Name] = arg.Value
+			continue
+		}
+		if part, ok := singleInterpolation(arg.Parts); ok {
+			val, ok := r.resolve(part)
+			if !ok {
+				return
+			}
+			values[arg.Prop.Name] = val
+			continue
+		}
+		s := ""
+		for _, part := range arg.Parts {
+			if part.Kind != wirparser.AstNodeKindInterpolation {
+				s += part.Text
+				continue
+			}
+			val, ok := r.lookup(part)
+			if !ok {
+				return
+			}
+			s += val
+		}
+		values[arg.Prop.Name] = s
+	}
+	inner := &htmlRenderer{
+		c:      u,
+		values: values,
+		types:  Scope{},
+		fills:  map[string]func(sb *strings.Builder){},
+	}
+	for _, fill := range r.c.Fills(n) {
+		inner.fills[fill.Slot.Name] = func(sb *strings.Builder) {
+			for _, child := range fill.Children {
+				r.render(sb, child)
+			}
+		}
+	}
+	for _, child := range u.Ast.Root.Children {
+		inner.render(sb, child)
+	}
+	r.diags = append(r.diags, inner.diags...)
+}
+
+// renderIf renders the first branch whose condition holds.
+func (r *htmlRenderer) renderIf(sb *strings.Builder, n *wirparser.AstNode) {
+	for _, branch := range n.Children {
+		if branch.Value != "" {
+			val, ok := r.resolve(branch)
+			if !ok {
+				return
+			}
+			b, ok := val.(bool)
+			if !ok {
+				r.diags = append(r.diags, r.c.Diag(branch.Span, "%s should be a bool but the props file has %s", branch.Value, jsonKind(val)))
+				return
+			}
+			if !b {
+				continue
+			}
+		}
+		for _, child := range branch.Children {
+			r.render(sb, child)
+		}
+		return
+	}
+}
+
+// renderSwitch renders the arm whose case matches the value, falling back to
+// the @default. Values of an enum must be one of its cases.
+func (r *htmlRenderer) renderSwitch(sb *strings.Builder, n *wirparser.AstNode) {
+	val, ok := r.resolve(n)
+	if !ok {
+		return
+	}
+	s, ok := val.(string)
+	if !ok {
+		r.diags = append(r.diags, r.c.Diag(n.Span, "%s should be a string but the props file has %s", n.Value, jsonKind(val)))
+		return
+	}
+	def, exists := r.c.Type(InterpolationType(n))
+	if exists && def.IsEnum() && !containsStr(def.Values, s) {
+		r.diags = append(r.diags, r.c.Diag(n.Span, "%s should be one of '%s' but the props file has '%s'", n.Value, strings.Join(def.Values, "' | '"), s))
+		return
+	}
+	for _, arm := range switchArms(n) {
+		if arm.Kind == wirparser.AstNodeKindSwitchDefault || arm.Value == s {
+			for _, child := range arm.Children {
+				r.render(sb, child)
+			}
+			return
+		}
+	}
+}
+
+func (r *htmlRenderer) renderFor(sb *strings.Builder, n *wirparser.AstNode) {
+	name := ForList(n)
+	val, exists := r.values[name]
+	if !exists {
+		r.diags = append(r.diags, r.c.Diag(n.Span, "no value for %s, add a list of %s to the props file", name, n.BindingType))
+		return
+	}
+	items, ok := val.([]any)
+	if !ok {
+		r.diags = append(r.diags, r.c.Diag(n.Span, "%s should be a list of %s but the props file has %s", name, n.BindingType, jsonKind(val)))
+		return
+	}
+	outerValues, outerTypes := r.values, r.types
+	defer func() {
+		r.values, r.types = outerValues, outerTypes
+	}()
+	for _, item := range items {
+		r.values = map[string]any{}
+		for k, v := range outerValues {
+			r.values[k] = v
+		}
+		r.values[n.Binding] = item
+		r.types = outerTypes.With(n.Binding, n.BindingType)
+		before := len(r.diags)
+		for _, child := range n.Children {
+			r.render(sb, child)
+		}
+		if len(r.diags) > before {
+			return
+		}
+	}
+}
+
+// resolve looks up the value of n against the props and the current @for
+// bindings.
+func (r *htmlRenderer) resolve(n *wirparser.AstNode) (any, bool) {
+	parts := strings.Split(n.Value, ".")
+	var val any = r.values
+	for i, part := range parts {
+		obj, ok := val.(map[string]any)
+		if !ok {
+			r.diags = append(r.diags, r.c.Diag(n.Span, "%s should be an object but the props file has %s", strings.Join(parts[:i], "."), jsonKind(val)))
+			return nil, false
+		}
+		val, ok = obj[part]
+		if !ok {
+			r.diags = append(r.diags, r.c.Diag(n.Span, "no value for %s, add it to the props file", strings.Join(parts[:i+1], ".")))
+			return nil, false
+		}
+	}
+	return val, true
+}
+
+// lookup resolves an interpolation and formats it as text.
+func (r *htmlRenderer) lookup(n *wirparser.AstNode) (string, bool) {
+	val, ok := r.resolve(n)
+	if !ok {
+		return "", false
+	}
+	t := r.c.ExprType(n.Value, r.types)
+	s, ok := formatValue(val, t)
+	if !ok {
+		r.diags = append(r.diags, r.c.Diag(n.Span, "%s should be a %s but the props file has %s", n.Value, t, jsonKind(val)))
+		return "", false
+	}
+	return s, true
+}
+
+func formatValue(val any, t string) (string, bool) {
+	switch t {
+	default:
+		{
+			return "", false
+		}
+	case "string":
+		{
+			s, ok := val.(string)
+			return s, ok
+		}
+	case "int":
+		{
+			f, ok := val.(float64)
+			if !ok || f != math.Trunc(f) {
+				return "", false
+			}
+			return strconv.FormatInt(int64(f), 10), true
+		}
+	case "float":
+		{
+			f, ok := val.(float64)
+			if !ok {
+				return "", false
+			}
+			return strconv.FormatFloat(f, 'f', -1, 64), true
+		}
+	case "bool":
+		{
+			b, ok := val.(bool)
+			if !ok {
+				return "", false
+			}
+			return strconv.FormatBool(b), true
+		}
+	}
+}
+
+// jsonKind describes a decoded JSON value for diagnostics.
+func jsonKind(val any) string {
+	switch v := val.(type) {
+	default:
+		{
+			return "null"
+		}
+	case string:
+		{
+			return "a string"
+		}
+	case float64:
+		{
+			if v == math.Trunc(v) {
+				return "an int"
+			}
+			return "a float"
+		}
+	case bool:
+		{
+			return "a bool"
+		}
+	case []any:
+		{
+			return "a list"
+		}
+	case map[string]any:
+		{
+			return "an object"
+		}
+	}
+}
+
+func isVoidElement(tag string) bool {
+	for _, v := range voidElements {
+		if v == tag {
+			return true
+		}
+	}
+	return false
+}
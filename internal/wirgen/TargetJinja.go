@@ -0,0 +1,195 @@
+package wirgen
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/phillip-england/wir/internal/wirparser"
+)
+
+// TargetJinja generates a Jinja2 template. It uses Jinja syntax such as
+// multi-line comments and with taking several comma separated values, so
+// Django templates can't render it. The template expects autoescaping to be
+// on and opens with a comment listing the context it renders. Defaults are
+// filled in with set. An imported component is included
+// with every prop and slot set around it, defaults and empty slots too, since
+// an included template also sees the context of the one including it. Slot
+// content is captured with a block set and the default slot is children.
+type TargetJinja struct{}
+
+func TargetJinjaNew(opts TargetOptions) (Target, error) {
+	return &TargetJinja{}, nil
+}
+
+func (t *TargetJinja) Ext() string {
+	return ".html.j2"
+}
+
+func (t *TargetJinja) Generate(c *Component) (string, error) {
+	d := &jinjaDialect{
+		c: c,
+	}
+	p := markupPrinterNew(d, 0)
+	p.nodes(c.Ast.Root.Children, Scope{})
+	var sb strings.Builder
+	sb.WriteString(header(c, "{#", " #}"))
+	sb.WriteString(contextComment(c, "{#", "#}"))
+	for _, prop := range c.Props {
+		if prop.Default != nil {
+			sb.WriteString("{% set " + prop.Name + " = " + prop.Name + "|default(" + literal(prop.Default, strconv.Quote, "[]") + ") %}\n")
+		}
+	}
+	sb.WriteString(p.out())
+	return sb.String(), nil
+}
+
+type jinjaDialect struct {
+	c *Component
+}
+
+// expr prints bools through lower so they render as true and false like the
+// other targets rather than as Python's True and False.
+func (d *jinjaDialect) expr(value string, scope Scope) string {
+	if d.c.ExprType(value, scope) == "bool" {
+		return "{{ " + value + "|lower }}"
+	}
+	return "{{ " + value + " }}"
+}
+
+func (d *jinjaDialect) attr(a wirparser.AstAttr, scope Scope) string {
+	if a.IsBool() {
+		return " " + a.Key
+	}
+	return " " + a.Key + "=\"" + joinParts(a.Parts, braceSafeText, func(n *wirparser.AstNode) string {
+		return d.expr(n.Value, scope)
+	}) + "\""
+}
+
+func (d *jinjaDialect) text(n *wirparser.AstNode, scope Scope) string {
+	return joinParts(n.Children, braceSafeText, func(part *wirparser.AstNode) string {
+		return d.expr(part.Value, scope)
+	})
+}
+
+func (d *jinjaDialect) loop(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	p.line("{% for " + n.Binding + " in " + ForList(n) + " %}")
+	p.indent++
+	p.nodes(n.Children, scope.With(n.Binding, n.BindingType))
+	p.indent--
+	p.line("{% endfor %}")
+}
+
+func (d *jinjaDialect) cond(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	p.branches(n.Children, scope, func(i int, branch *wirparser.AstNode) string {
+		switch {
+		default:
+			{
+				return "{% elif " + branch.Value + " %}"
+			}
+		case i == 0:
+			{
+				return "{% if " + branch.Value + " %}"
+			}
+		case branch.Value == "":
+			{
+				return "{% else %}"
+			}
+		}
+	}, "{% endif %}")
+}
+
+// match lowers a @switch to an if chain since Jinja has no switch tag.
+func (d *jinjaDialect) match(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	d.cond(p, switchAsIf(n, func(lit string) string {
+		return n.Value + " == " + strconv.Quote(lit)
+	}), scope)
+}
+
+func (d *jinjaDialect) component(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	u, _ := d.c.Import(n.TagName)
+	var vars []string
+	for _, arg := range d.c.Args(n, true) {
+		value := literal(arg.Value, strconv.Quote, "[]")
+		if arg.Parts != nil {
+			value = d.value(arg.Parts, scope)
+		}
+		vars = append(vars, arg.Prop.Name+"="+value)
+	}
+	include := "{% include " + strconv.Quote(templateName(u, ".html.j2")) + " %}"
+	if len(vars) == 0 && len(u.Slots) == 0 {
+		p.line(include)
+		return
+	}
+	if len(vars) == 0 {
+		p.line("{% with %}")
+	} else {
+		p.line("{% with " + strings.Join(vars, ", ") + " %}")
+	}
+	p.indent++
+	fills := d.c.Fills(n)
+	for _, s := range u.Slots {
+		name := slotName(s, "children")
+		fill, filled := findFill(fills, s)
+		if !filled {
+			p.line("{% set " + name + " = \"\" %}")
+			continue
+		}
+		p.line("{% set " + name + " %}")
+		p.indent++
+		p.nodes(fill.Children, scope)
+		p.indent--
+		p.line("{% endset %}")
+	}
+	p.line(include)
+	p.indent--
+	p.line("{% endwith %}")
+}
+
+// value renders attribute parts as a Jinja expression, joining text and
+// values with ~.
+func (d *jinjaDialect) value(parts []*wirparser.AstNode, scope Scope) string {
+	if n, ok := singleInterpolation(parts); ok {
+		return n.Value
+	}
+	var terms []string
+	for _, part := range parts {
+		switch {
+		default:
+			{
+				terms = append(terms, strconv.Quote(part.Text))
+			}
+		case part.Kind == wirparser.AstNodeKindInterpolation && d.c.ExprType(part.Value, scope) == "bool":
+			{
+				terms = append(terms, part.Value+"|lower")
+			}
+		case part.Kind == wirparser.AstNodeKindInterpolation:
+			{
+				terms = append(terms, part.Value)
+			}
+		}
+	}
+	return strings.Join(terms, " ~ ")
+}
+
+// slot renders the content set for the slot, which is empty when it wasn't
+// filled.
+func (d *jinjaDialect) slot(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	name := slotName(Slot{Name: n.Value}, "children")
+	if len(n.Children) == 0 {
+		p.line("{{ " + name + " }}")
+		return
+	}
+	p.line("{% if " + name + " %}")
+	p.indent++
+	p.line("{{ " + name + " }}")
+	p.indent--
+	p.line("{% else %}")
+	p.indent++
+	p.nodes(n.Children, scope)
+	p.indent--
+	p.line("{% endif %}")
+}
+
+func (d *jinjaDialect) voidEnd() string {
+	return ">"
+}
@@ -0,0 +1,231 @@
+package wirgen
+
+import (
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/phillip-england/wir/internal/wirparser"
+)
+
+// TargetLit generates a LitElement class with a reactive property for every
+// prop. Imported components are used as their custom elements, setting the
+// properties passed values through property bindings.
+type TargetLit struct{}
+
+func TargetLitNew(opts TargetOptions) (Target, error) {
+	return &TargetLit{}, nil
+}
+
+func (t *TargetLit) Ext() string {
+	return ".ts"
+}
+
+func (t *TargetLit) Generate(c *Component) (string, error) {
+	d := &litDialect{
+		c: c,
+	}
+	p := markupPrinterNew(d, 3)
+	p.nodes(c.Ast.Root.Children, Scope{})
+	var sb strings.Builder
+	sb.WriteString(header(c, "//", "") + "\n")
+	if d.usesNothing {
+		sb.WriteString("import { LitElement, html, nothing } from \"lit\";\n")
+	} else {
+		sb.WriteString("import { LitElement, html } from \"lit\";\n")
+	}
+	if len(c.Props) > 0 {
+		sb.WriteString("import { customElement, property } from \"lit/decorators.js\";\n")
+	} else {
+		sb.WriteString("import { customElement } from \"lit/decorators.js\";\n")
+	}
+	if d.usesRepeat {
+		sb.WriteString("import { repeat } from \"lit/directives/repeat.js\";\n")
+	}
+	for _, u := range c.Imports {
+		sb.WriteString("import " + strconv.Quote(importPath(c, u, ".js")) + ";\n")
+	}
+	sb.WriteString("\n")
+	for _, def := range c.Types {
+		sb.WriteString(tsTypeDef(def, "", true) + "\n")
+	}
+	sb.WriteString("@customElement(" + strconv.Quote(customElementName(c)) + ")\n")
+	sb.WriteString("export class " + c.Name + " extends LitElement {\n")
+	for _, prop := range c.Props {
+		sb.WriteString("  @property(" + litPropertyOptions(c, prop) + ")\n")
+		sb.WriteString("  " + prop.Name + ": " + tsType(prop.Type) + " = " + litDefault(c, prop) + ";\n\n")
+	}
+	sb.WriteString("  render() {\n")
+	sb.WriteString("    return html`\n")
+	sb.WriteString(p.out())
+	sb.WriteString("    `;\n")
+	sb.WriteString("  }\n")
+	sb.WriteString("}\n")
+	return sb.String(), nil
+}
+
+// litPropertyOptions declares how a property converts from its attribute,
+// which is the kebab case prop name.
+func litPropertyOptions(c *Component, prop Field) string {
+	t := "Object"
+	switch {
+	case IsList(prop.Type):
+		t = "Array"
+	case prop.Type == "string" || c.IsEnum(prop.Type):
+		t = "String"
+	case prop.Type == "int" || prop.Type == "float":
+		t = "Number"
+	case prop.Type == "bool":
+		t = "Boolean"
+	}
+	opts := "{ type: " + t
+	if attr := wcAttrName(prop.Name); attr != strings.ToLower(prop.Name) {
+		opts += ", attribute: " + strconv.Quote(attr)
+	}
+	return opts + " }"
+}
+
+// litDefault starts a property at the default @props declares for it. An enum
+// without one starts at its first value so it type checks.
+func litDefault(c *Component, prop Field) string {
+	if prop.Default != nil {
+		return jsLiteral(prop.Default)
+	}
+	def, exists := c.Type(prop.Type)
+	if exists && def.IsEnum() {
+		return strconv.Quote(def.Values[0])
+	}
+	return wcDefault(prop.Type)
+}
+
+type litDialect struct {
+	c           *Component
+	usesRepeat  bool
+	usesNothing bool
+}
+
+func (d *litDialect) expr(value string, scope Scope) string {
+	if scope.Has(strings.Split(value, ".")[0]) {
+		return value
+	}
+	return "this." + value
+}
+
+// attr binds a lone bool interpolation with ?key so the attribute is toggled
+// rather than set to "true" or "false".
+func (d *litDialect) attr(a wirparser.AstAttr, scope Scope) string {
+	if a.IsBool() {
+		return " " + a.Key
+	}
+	if n, ok := singleInterpolation(a.Parts); ok && d.c.ExprType(n.Value, scope) == "bool" {
+		return " ?" + a.Key + "=${" + d.expr(n.Value, scope) + "}"
+	}
+	return " " + a.Key + "=\"" + joinParts(a.Parts, litText, func(n *wirparser.AstNode) string {
+		return "${" + d.expr(n.Value, scope) + "}"
+	}) + "\""
+}
+
+func (d *litDialect) text(n *wirparser.AstNode, scope Scope) string {
+	return joinParts(n.Children, litText, func(part *wirparser.AstNode) string {
+		return "${" + d.expr(part.Value, scope) + "}"
+	})
+}
+
+func (d *litDialect) loop(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	d.usesRepeat = true
+	index := n.Binding + "Index"
+	p.line("${repeat(" + d.expr(ForList(n), scope) + ", (_, " + index + ") => " + index + ", (" + n.Binding + ") => html`")
+	p.indent++
+	p.nodes(n.Children, scope.With(n.Binding, n.BindingType))
+	p.indent--
+	p.line("`)}")
+}
+
+// cond renders an @if chain as nested conditional expressions, rendering
+// nothing when no branch applies.
+func (d *litDialect) cond(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	d.chain(p, n, scope, func(value string) string {
+		return d.expr(value, scope)
+	})
+}
+
+// match compares the value of a @switch against each case in the same
+// conditional expressions as an @if chain.
+func (d *litDialect) match(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	value := d.expr(n.Value, scope)
+	d.chain(p, switchAsIf(n, func(lit string) string {
+		return value + " === " + strconv.Quote(lit)
+	}), scope, func(test string) string {
+		return test
+	})
+}
+
+// chain renders the branches of n, writing each condition with test.
+func (d *litDialect) chain(p *markupPrinter, n *wirparser.AstNode, scope Scope, test func(value string) string) {
+	end := "`}"
+	if n.Children[len(n.Children)-1].Value != "" {
+		d.usesNothing = true
+		end = "` : nothing}"
+	}
+	p.branches(n.Children, scope, func(i int, branch *wirparser.AstNode) string {
+		switch {
+		default:
+			{
+				return "` : " + test(branch.Value) + " ? html`"
+			}
+		case i == 0:
+			{
+				return "${" + test(branch.Value) + " ? html`"
+			}
+		case branch.Value == "":
+			{
+				return "` : html`"
+			}
+		}
+	}, end)
+}
+
+func (d *litDialect) component(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	u, _ := d.c.Import(n.TagName)
+	tag := customElementName(u)
+	out := "<" + tag
+	for _, arg := range d.c.Args(n, false) {
+		s, isString := arg.Value.(string)
+		part, isSingle := singleInterpolation(arg.Parts)
+		switch {
+		default:
+			{
+				out += " ." + arg.Prop.Name + "=${" + jsLiteral(arg.Value) + "}"
+			}
+		case isSingle:
+			{
+				out += " ." + arg.Prop.Name + "=${" + d.expr(part.Value, scope) + "}"
+			}
+		case arg.Parts != nil:
+			{
+				out += d.attr(wirparser.AstAttr{Key: wcAttrName(arg.Prop.Name), Parts: arg.Parts}, scope)
+			}
+		case isString:
+			{
+				out += " " + wcAttrName(arg.Prop.Name) + "=\"" + litText(s) + "\""
+			}
+		}
+	}
+	p.use(out, "</"+tag+">", "></"+tag+">", d.c.Fills(n), scope, func(fill Fill) {
+		p.node(slotted(fill), scope)
+	})
+}
+
+// slot renders a native <slot>, which Lit projects content into through the
+// shadow root of the element.
+func (d *litDialect) slot(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	p.element(slotElement(n), scope, "")
+}
+
+func (d *litDialect) voidEnd() string {
+	return ">"
+}
+
+func litText(s string) string {
+	return jsTemplate(html.EscapeString(s))
+}
@@ -0,0 +1,382 @@
+package wirgen
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/phillip-england/wir/internal/wirparser"
+)
+
+// reactAttrs are the html attributes React spells differently.
+var reactAttrs = map[string]string{
+	"class":           "className",
+	"for":             "htmlFor",
+	"tabindex":        "tabIndex",
+	"readonly":        "readOnly",
+	"maxlength":       "maxLength",
+	"minlength":       "minLength",
+	"colspan":         "colSpan",
+	"rowspan":         "rowSpan",
+	"contenteditable": "contentEditable",
+	"autocomplete":    "autoComplete",
+	"autofocus":       "autoFocus",
+}
+
+// TargetReact generates a typed React function component per .wir file.
+type TargetReact struct{}
+
+func TargetReactNew(opts TargetOptions) (Target, error) {
+	return &TargetReact{}, nil
+}
+
+func (t *TargetReact) Ext() string {
+	return ".tsx"
+}
+
+func (t *TargetReact) Generate(c *Component) (string, error) {
+	d := &reactDialect{
+		c: c,
+	}
+	body := jsxRoot(d, c, 2, jsxElement)
+	var sb strings.Builder
+	sb.WriteString(header(c, "//", "") + "\n")
+	var imports []string
+	if d.usesFragment {
+		imports = append(imports, "Fragment")
+	}
+	if len(c.Slots) > 0 {
+		imports = append(imports, "type ReactNode")
+	}
+	if len(imports) > 0 {
+		sb.WriteString("import { " + strings.Join(imports, ", ") + " } from \"react\";\n")
+	}
+	sb.WriteString(jsImports(c, ""))
+	if len(imports) > 0 || len(c.Imports) > 0 {
+		sb.WriteString("\n")
+	}
+	if interfaces := tsInterfaces(c, "", true, "ReactNode"); interfaces != "" {
+		sb.WriteString(interfaces + "\n")
+	}
+	sb.WriteString("export default function " + c.Name + "(" + jsPropsParam(c) + ") {\n")
+	sb.WriteString(body)
+	sb.WriteString("}\n")
+	return sb.String(), nil
+}
+
+// jsxRoot renders the return statement of a JSX component. Unless the root
+// is a single node that tag reports renders as a tag, it is wrapped in a
+// fragment, since a bare expression container isn't valid JSX.
+func jsxRoot(d markupDialect, c *Component, indent int, tag func(n *wirparser.AstNode) bool) string {
+	pad := strings.Repeat("  ", indent-1)
+	roots := c.Ast.Root.Children
+	if len(roots) == 0 {
+		return pad + "return null;\n"
+	}
+	p := markupPrinterNew(d, indent)
+	if len(roots) > 1 || !tag(roots[0]) {
+		p.line("<>")
+		p.indent++
+		p.nodes(roots, Scope{})
+		p.indent--
+		p.line("</>")
+	} else {
+		p.nodes(roots, Scope{})
+	}
+	return pad + "return (\n" + p.out() + pad + ");\n"
+}
+
+func jsxElement(n *wirparser.AstNode) bool {
+	return n.Kind == wirparser.AstNodeKindElement || n.Kind == wirparser.AstNodeKindComponent
+}
+
+// jsPropsParam destructures the props and slots of c in a function
+// signature, giving props with a default their default value.
+func jsPropsParam(c *Component) string {
+	if len(c.Props) == 0 && len(c.Slots) == 0 {
+		return ""
+	}
+	var names []string
+	for _, p := range c.Props {
+		if p.Default != nil {
+			names = append(names, p.Name+" = "+jsLiteral(p.Default))
+			continue
+		}
+		names = append(names, p.Name)
+	}
+	for _, s := range c.Slots {
+		names = append(names, slotName(s, "children"))
+	}
+	return "{ " + strings.Join(names, ", ") + " }: Props"
+}
+
+// jsImports imports the default export of each component c imports from the
+// file the same target generates for it.
+func jsImports(c *Component, ext string) string {
+	out := ""
+	for _, u := range c.Imports {
+		out += "import " + u.Name + " from " + strconv.Quote(importPath(c, u, ext)) + ";\n"
+	}
+	return out
+}
+
+type reactDialect struct {
+	c            *Component
+	usesFragment bool
+}
+
+func (d *reactDialect) attr(a wirparser.AstAttr, scope Scope) string {
+	key := a.Key
+	if renamed, exists := reactAttrs[key]; exists {
+		key = renamed
+	}
+	return " " + key + jsxAttrValue(a, jsxValue)
+}
+
+func jsxValue(n *wirparser.AstNode) string {
+	return n.Value
+}
+
+// jsxAttrValue renders an attribute value in JSX, translating interpolations
+// with expr. It is shared with Solid.
+func jsxAttrValue(a wirparser.AstAttr, expr func(n *wirparser.AstNode) string) string {
+	if a.IsBool() {
+		return ""
+	}
+	if n, ok := singleInterpolation(a.Parts); ok {
+		return "={" + expr(n) + "}"
+	}
+	hasExpr := false
+	for _, part := range a.Parts {
+		if part.Kind == wirparser.AstNodeKindInterpolation {
+			hasExpr = true
+		}
+	}
+	if !hasExpr {
+		s := joinParts(a.Parts, func(s string) string { return s }, nil)
+		if strings.Contains(s, "\"") {
+			return "={" + strconv.Quote(s) + "}"
+		}
+		return "=\"" + s + "\""
+	}
+	return "={" + jsxExpr(a.Parts, expr) + "}"
+}
+
+// jsxExpr renders parts holding at least one interpolation as a JavaScript
+// expression.
+func jsxExpr(parts []*wirparser.AstNode, expr func(n *wirparser.AstNode) string) string {
+	if n, ok := singleInterpolation(parts); ok {
+		return expr(n)
+	}
+	return "`" + joinParts(parts, jsTemplate, func(n *wirparser.AstNode) string {
+		return "${" + expr(n) + "}"
+	}) + "`"
+}
+
+func (d *reactDialect) text(n *wirparser.AstNode, scope Scope) string {
+	return joinParts(n.Children, jsxText, func(part *wirparser.AstNode) string {
+		if d.c.ExprType(part.Value, scope) == "bool" {
+			return "{String(" + part.Value + ")}"
+		}
+		return "{" + part.Value + "}"
+	})
+}
+
+// jsxText escapes the characters that are not allowed in JSX text.
+func jsxText(s string) string {
+	return strings.NewReplacer("{", "{\"{\"}", "}", "{\"}\"}", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+func (d *reactDialect) loop(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	index := n.Binding + "Index"
+	p.line("{" + ForList(n) + ".map((" + n.Binding + ", " + index + ") => (")
+	p.indent++
+	inner := scope.With(n.Binding, n.BindingType)
+	key := " key={" + index + "}"
+	if len(n.Children) == 1 && n.Children[0].Kind == wirparser.AstNodeKindElement {
+		p.element(n.Children[0], inner, key)
+	} else {
+		d.usesFragment = true
+		p.line("<Fragment" + key + ">")
+		p.indent++
+		p.nodes(n.Children, inner)
+		p.indent--
+		p.line("</Fragment>")
+	}
+	p.indent--
+	p.line("))}")
+}
+
+// cond renders a lone @if with && and a chain as nested ternaries.
+func (d *reactDialect) cond(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	last := n.Children[len(n.Children)-1]
+	if len(n.Children) == 1 {
+		p.line("{" + last.Value + " && (")
+		p.indent++
+		jsxBody(p, last.Children, scope)
+		p.indent--
+		p.line(")}")
+		return
+	}
+	for i, branch := range n.Children {
+		switch {
+		default:
+			{
+				p.line(") : " + branch.Value + " ? (")
+			}
+		case i == 0:
+			{
+				p.line("{" + branch.Value + " ? (")
+			}
+		case branch.Value == "":
+			{
+				p.line(") : (")
+			}
+		}
+		p.indent++
+		jsxBody(p, branch.Children, scope)
+		p.indent--
+	}
+	if last.Value == "" {
+		p.line(")}")
+	} else {
+		p.line(") : null}")
+	}
+}
+
+// match compares the value of a @switch against each case in a chain of
+// ternaries.
+func (d *reactDialect) match(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	d.cond(p, switchAsIf(n, func(lit string) string {
+		return n.Value + " === " + strconv.Quote(lit)
+	}), scope)
+}
+
+// jsxBody renders nodes as a single JSX expression, wrapping several in a
+// fragment.
+func jsxBody(p *markupPrinter, nodes []*wirparser.AstNode, scope Scope) {
+	switch {
+	default:
+		{
+			p.line("<>")
+			p.indent++
+			p.nodes(nodes, scope)
+			p.indent--
+			p.line("</>")
+		}
+	case len(nodes) == 0:
+		{
+			p.line("null")
+		}
+	case len(nodes) == 1 && (nodes[0].Kind == wirparser.AstNodeKindElement || nodes[0].Kind == wirparser.AstNodeKindComponent):
+		{
+			p.nodes(nodes, scope)
+		}
+	}
+}
+
+func (d *reactDialect) component(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	jsxComponent(p, d.c, n, scope, func(arg Arg) string {
+		if arg.Parts != nil {
+			return jsxAttrValue(wirparser.AstAttr{Parts: arg.Parts}, jsxValue)
+		}
+		return jsxStatic(arg.Value)
+	})
+}
+
+// jsxComponent renders the use of a component, writing the value of each
+// prop passed with value. Content for a named slot is passed as a prop of
+// its own, which puts every prop on its own line, and content for the
+// default slot as children.
+func jsxComponent(p *markupPrinter, c *Component, n *wirparser.AstNode, scope Scope, value func(arg Arg) string) {
+	var attrs []string
+	for _, arg := range c.Args(n, false) {
+		attrs = append(attrs, arg.Prop.Name+value(arg))
+	}
+	var children []*wirparser.AstNode
+	var named []Fill
+	for _, fill := range c.Fills(n) {
+		if fill.Slot.Name == "" {
+			children = fill.Children
+			continue
+		}
+		named = append(named, fill)
+	}
+	end := ">"
+	if len(children) == 0 {
+		end = "/>"
+	}
+	if len(named) == 0 {
+		open := "<" + n.TagName
+		for _, attr := range attrs {
+			open += " " + attr
+		}
+		if len(children) == 0 {
+			end = " />"
+		}
+		p.line(open + end)
+	} else {
+		p.line("<" + n.TagName)
+		p.indent++
+		for _, attr := range attrs {
+			p.line(attr)
+		}
+		for _, fill := range named {
+			p.line(fill.Slot.Name + "={")
+			p.indent++
+			jsxBody(p, fill.Children, scope)
+			p.indent--
+			p.line("}")
+		}
+		p.indent--
+		p.line(end)
+	}
+	if len(children) == 0 {
+		return
+	}
+	p.indent++
+	p.nodes(children, scope)
+	p.indent--
+	p.line("</" + n.TagName + ">")
+}
+
+func (d *reactDialect) slot(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	jsxSlot(p, slotName(Slot{Name: n.Value}, "children"), n, scope)
+}
+
+// jsxSlot renders the content passed to the @slot n, which value reads,
+// falling back to the children of n.
+func jsxSlot(p *markupPrinter, value string, n *wirparser.AstNode, scope Scope) {
+	if len(n.Children) == 0 {
+		p.line("{" + value + "}")
+		return
+	}
+	p.line("{" + value + " ?? (")
+	p.indent++
+	jsxBody(p, n.Children, scope)
+	p.indent--
+	p.line(")}")
+}
+
+// jsxStatic renders a static prop value, passing true the way a bare
+// attribute would.
+func jsxStatic(v any) string {
+	s, isString := v.(string)
+	switch {
+	default:
+		{
+			return "={" + jsLiteral(v) + "}"
+		}
+	case v == true:
+		{
+			return ""
+		}
+	case isString && !strings.Contains(s, "\""):
+		{
+			return "=\"" + s + "\""
+		}
+	}
+}
+
+func (d *reactDialect) voidEnd() string {
+	return " />"
+}
@@ -0,0 +1,229 @@
+package wirgen
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/phillip-england/wir/internal/wirparser"
+)
+
+// TargetSolid generates a SolidJS component whose props are signal accessors,
+// so each interpolation subscribes to its own signal and updates in place.
+type TargetSolid struct{}
+
+func TargetSolidNew(opts TargetOptions) (Target, error) {
+	return &TargetSolid{}, nil
+}
+
+func (t *TargetSolid) Ext() string {
+	return ".tsx"
+}
+
+func (t *TargetSolid) Generate(c *Component) (string, error) {
+	d := &solidDialect{
+		c: c,
+	}
+	body := jsxRoot(d, c, 2, solidTag)
+	var sb strings.Builder
+	sb.WriteString(header(c, "//", "") + "\n")
+	var imports []string
+	if d.usesFor {
+		imports = append(imports, "For")
+	}
+	if d.usesSwitch {
+		imports = append(imports, "Match")
+	}
+	if c.HasDefaults() {
+		imports = append(imports, "mergeProps")
+	}
+	if d.usesShow {
+		imports = append(imports, "Show")
+	}
+	if d.usesSwitch {
+		imports = append(imports, "Switch")
+	}
+	if len(c.Props) > 0 {
+		imports = append(imports, "type Accessor")
+	}
+	if len(c.Slots) > 0 {
+		imports = append(imports, "type JSX")
+	}
+	if len(imports) > 0 {
+		sb.WriteString("import { " + strings.Join(imports, ", ") + " } from \"solid-js\";\n")
+	}
+	sb.WriteString(jsImports(c, ""))
+	if len(imports) > 0 || len(c.Imports) > 0 {
+		sb.WriteString("\n")
+	}
+	if len(c.Props) > 0 || len(c.Slots) > 0 {
+		for _, def := range c.Types {
+			sb.WriteString(tsTypeDef(def, "", true) + "\n")
+		}
+		sb.WriteString("export interface Props {\n")
+		for _, prop := range c.Props {
+			sb.WriteString("  " + prop.Name + tsOptional(prop) + ": Accessor<" + tsType(prop.Type) + ">;\n")
+		}
+		for _, s := range c.Slots {
+			sb.WriteString("  " + slotName(s, "children") + "?: JSX.Element;\n")
+		}
+		sb.WriteString("}\n\n")
+		if c.HasDefaults() {
+			sb.WriteString("export default function " + c.Name + "(passed: Props) {\n")
+			sb.WriteString("  const props = mergeProps(" + solidDefaults(c) + ", passed);\n")
+		} else {
+			sb.WriteString("export default function " + c.Name + "(props: Props) {\n")
+		}
+	} else {
+		sb.WriteString("export default function " + c.Name + "() {\n")
+	}
+	sb.WriteString(body)
+	sb.WriteString("}\n")
+	return sb.String(), nil
+}
+
+// solidDefaults is the object mergeProps fills in props that weren't passed
+// from, with each default behind an accessor like the props themselves.
+func solidDefaults(c *Component) string {
+	var defaults []string
+	for _, prop := range c.Props {
+		if prop.Default != nil {
+			defaults = append(defaults, prop.Name+": () => "+jsLiteral(prop.Default))
+		}
+	}
+	return "{ " + strings.Join(defaults, ", ") + " }"
+}
+
+type solidDialect struct {
+	c          *Component
+	usesFor    bool
+	usesShow   bool
+	usesSwitch bool
+}
+
+// expr reads props through their accessors while @for bindings, which For
+// hands over as plain values, are used directly.
+func (d *solidDialect) expr(value string, scope Scope) string {
+	root := strings.Split(value, ".")[0]
+	if scope.Has(root) {
+		return value
+	}
+	return "props." + value + "()"
+}
+
+func (d *solidDialect) attr(a wirparser.AstAttr, scope Scope) string {
+	return " " + a.Key + jsxAttrValue(a, func(n *wirparser.AstNode) string {
+		return d.expr(n.Value, scope)
+	})
+}
+
+func (d *solidDialect) text(n *wirparser.AstNode, scope Scope) string {
+	return joinParts(n.Children, jsxText, func(part *wirparser.AstNode) string {
+		if d.c.ExprType(part.Value, scope) == "bool" {
+			return "{String(" + d.expr(part.Value, scope) + ")}"
+		}
+		return "{" + d.expr(part.Value, scope) + "}"
+	})
+}
+
+func (d *solidDialect) loop(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	d.usesFor = true
+	inner := scope.With(n.Binding, n.BindingType)
+	p.line("<For each={" + d.expr(ForList(n), scope) + "}>")
+	p.indent++
+	p.line("{(" + n.Binding + ") => (")
+	p.indent++
+	if len(n.Children) == 1 && n.Children[0].Kind == wirparser.AstNodeKindElement {
+		p.nodes(n.Children, inner)
+	} else {
+		p.line("<>")
+		p.indent++
+		p.nodes(n.Children, inner)
+		p.indent--
+		p.line("</>")
+	}
+	p.indent--
+	p.line(")}")
+	p.indent--
+	p.line("</For>")
+}
+
+// cond renders a lone @if with Show and a chain with Switch, where an @else
+// is the Match that always applies.
+func (d *solidDialect) cond(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	if len(n.Children) == 1 {
+		d.usesShow = true
+		p.line("<Show when={" + d.expr(n.Children[0].Value, scope) + "}>")
+		p.indent++
+		p.nodes(n.Children[0].Children, scope)
+		p.indent--
+		p.line("</Show>")
+		return
+	}
+	d.matches(p, n.Children, scope, func(branch *wirparser.AstNode) string {
+		if branch.Value == "" {
+			return "true"
+		}
+		return d.expr(branch.Value, scope)
+	})
+}
+
+// match renders a @switch as a Switch comparing its value in each Match.
+func (d *solidDialect) match(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	value := d.expr(n.Value, scope)
+	d.matches(p, switchArms(n), scope, func(arm *wirparser.AstNode) string {
+		if arm.Kind == wirparser.AstNodeKindSwitchDefault {
+			return "true"
+		}
+		return value + " === " + strconv.Quote(arm.Value)
+	})
+}
+
+// matches wraps each of branches in a Match whose condition is when.
+func (d *solidDialect) matches(p *markupPrinter, branches []*wirparser.AstNode, scope Scope, when func(branch *wirparser.AstNode) string) {
+	d.usesSwitch = true
+	p.line("<Switch>")
+	p.indent++
+	for _, branch := range branches {
+		p.line("<Match when={" + when(branch) + "}>")
+		p.indent++
+		p.nodes(branch.Children, scope)
+		p.indent--
+		p.line("</Match>")
+	}
+	p.indent--
+	p.line("</Switch>")
+}
+
+// component passes each prop behind an accessor, handing a prop of c over as
+// the accessor it already is. Slot content is passed as is.
+func (d *solidDialect) component(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	jsxComponent(p, d.c, n, scope, func(arg Arg) string {
+		if arg.Parts == nil {
+			return "={() => " + jsLiteral(arg.Value) + "}"
+		}
+		part, ok := singleInterpolation(arg.Parts)
+		if ok && !strings.Contains(part.Value, ".") && !scope.Has(part.Value) {
+			return "={props." + part.Value + "}"
+		}
+		return "={() => " + jsxExpr(arg.Parts, func(n *wirparser.AstNode) string {
+			return d.expr(n.Value, scope)
+		}) + "}"
+	})
+}
+
+func (d *solidDialect) slot(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	jsxSlot(p, "props."+slotName(Slot{Name: n.Value}, "children"), n, scope)
+}
+
+// solidTag reports whether n renders as a tag, which for Solid includes the
+// control flow directives.
+func solidTag(n *wirparser.AstNode) bool {
+	if n.Kind == wirparser.AstNodeKindForDirective || n.Kind == wirparser.AstNodeKindIfDirective || n.Kind == wirparser.AstNodeKindSwitchDirective {
+		return true
+	}
+	return jsxElement(n)
+}
+
+func (d *solidDialect) voidEnd() string {
+	return " />"
+}
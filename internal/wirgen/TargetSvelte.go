@@ -0,0 +1,156 @@
+package wirgen
+
+import (
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/phillip-england/wir/internal/wirparser"
+)
+
+// TargetSvelte generates a Svelte component declaring an export let for every
+// prop.
+type TargetSvelte struct{}
+
+func TargetSvelteNew(opts TargetOptions) (Target, error) {
+	return &TargetSvelte{}, nil
+}
+
+func (t *TargetSvelte) Ext() string {
+	return ".svelte"
+}
+
+func (t *TargetSvelte) Generate(c *Component) (string, error) {
+	p := markupPrinterNew(&svelteDialect{
+		c: c,
+	}, 0)
+	p.nodes(c.Ast.Root.Children, Scope{})
+	var sb strings.Builder
+	sb.WriteString(header(c, "<!--", " -->"))
+	if len(c.Props) > 0 || len(c.Imports) > 0 {
+		sb.WriteString("<script lang=\"ts\">\n")
+		for _, u := range c.Imports {
+			sb.WriteString("  import " + u.Name + " from " + strconv.Quote(importPath(c, u, ".svelte")) + ";\n")
+		}
+		if len(c.Imports) > 0 && len(c.Props) > 0 {
+			sb.WriteString("\n")
+		}
+		for _, def := range c.Types {
+			sb.WriteString(tsTypeDef(def, "  ", false) + "\n")
+		}
+		for _, prop := range c.Props {
+			if prop.Default != nil {
+				sb.WriteString("  export let " + prop.Name + ": " + tsType(prop.Type) + " = " + jsLiteral(prop.Default) + ";\n")
+				continue
+			}
+			sb.WriteString("  export let " + prop.Name + ": " + tsType(prop.Type) + ";\n")
+		}
+		sb.WriteString("</script>\n\n")
+	}
+	sb.WriteString(p.out())
+	return sb.String(), nil
+}
+
+type svelteDialect struct {
+	c *Component
+}
+
+// attr writes interpolations straight into attribute values, which Svelte
+// supports for partial values such as class="bg-black {someClass}".
+func (d *svelteDialect) attr(a wirparser.AstAttr, scope Scope) string {
+	if a.IsBool() {
+		return " " + a.Key
+	}
+	if n, ok := singleInterpolation(a.Parts); ok {
+		return " " + a.Key + "={" + n.Value + "}"
+	}
+	return " " + a.Key + "=\"" + joinParts(a.Parts, svelteText, svelteExpr) + "\""
+}
+
+func (d *svelteDialect) text(n *wirparser.AstNode, scope Scope) string {
+	return joinParts(n.Children, svelteText, svelteExpr)
+}
+
+func (d *svelteDialect) loop(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	p.line("{#each " + ForList(n) + " as " + n.Binding + "}")
+	p.indent++
+	p.nodes(n.Children, scope.With(n.Binding, n.BindingType))
+	p.indent--
+	p.line("{/each}")
+}
+
+func (d *svelteDialect) cond(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	p.branches(n.Children, scope, func(i int, branch *wirparser.AstNode) string {
+		switch {
+		default:
+			{
+				return "{:else if " + branch.Value + "}"
+			}
+		case i == 0:
+			{
+				return "{#if " + branch.Value + "}"
+			}
+		case branch.Value == "":
+			{
+				return "{:else}"
+			}
+		}
+	}, "{/if}")
+}
+
+// match lowers a @switch to an if chain since Svelte has no switch block.
+func (d *svelteDialect) match(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	d.cond(p, switchAsIf(n, func(lit string) string {
+		return n.Value + " === " + strconv.Quote(lit)
+	}), scope)
+}
+
+func (d *svelteDialect) component(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	out := "<" + n.TagName
+	for _, arg := range d.c.Args(n, false) {
+		s, isString := arg.Value.(string)
+		switch {
+		default:
+			{
+				out += " " + arg.Prop.Name + "={" + jsLiteral(arg.Value) + "}"
+			}
+		case arg.Parts != nil:
+			{
+				out += d.attr(wirparser.AstAttr{Key: arg.Prop.Name, Parts: arg.Parts}, scope)
+			}
+		case arg.Value == true:
+			{
+				out += " " + arg.Prop.Name
+			}
+		case isString:
+			{
+				out += " " + arg.Prop.Name + "=\"" + svelteText(s) + "\""
+			}
+		}
+	}
+	p.use(out, "</"+n.TagName+">", " />", d.c.Fills(n), scope, func(fill Fill) {
+		p.line("<svelte:fragment slot=\"" + fill.Slot.Name + "\">")
+		p.indent++
+		p.nodes(fill.Children, scope)
+		p.indent--
+		p.line("</svelte:fragment>")
+	})
+}
+
+func (d *svelteDialect) slot(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	p.element(slotElement(n), scope, "")
+}
+
+func (d *svelteDialect) voidEnd() string {
+	return " />"
+}
+
+// svelteText escapes static text, including the braces Svelte would otherwise
+// read as an expression.
+func svelteText(s string) string {
+	return strings.NewReplacer("{", "&#123;", "}", "&#125;").Replace(html.EscapeString(s))
+}
+
+func svelteExpr(n *wirparser.AstNode) string {
+	return "{" + n.Value + "}"
+}
@@ -0,0 +1,388 @@
+package wirgen
+
+import (
+	"strings"
+
+	"github.com/phillip-england/wir/internal/wirdiag"
+	"github.com/phillip-england/wir/internal/wirparser"
+)
+
+// swiftTextElements hold a single line of text and the modifier applied to it.
+var swiftTextElements = map[string]string{
+	"h1": ".font(.largeTitle)",
+	"p":  "",
+}
+
+// TargetSwiftUI generates a SwiftUI View struct. Only h1, p, button, ul, li
+// and div have a native mapping; html attributes have no SwiftUI equivalent
+// and are left out. Imported views are expected in the same module, so they
+// are used without an import. Slots are optional AnyView properties, where
+// content is the default slot.
+type TargetSwiftUI struct{}
+
+func TargetSwiftUINew(opts TargetOptions) (Target, error) {
+	return &TargetSwiftUI{}, nil
+}
+
+func (t *TargetSwiftUI) Ext() string {
+	return ".swift"
+}
+
+func (t *TargetSwiftUI) Generate(c *Component) (string, error) {
+	g := &swiftGen{
+		lines: lines{
+			unit: "    ",
+		},
+		c: c,
+	}
+	g.line(header(c, "//", ""))
+	g.line("import SwiftUI")
+	g.line("")
+	for _, def := range c.Types {
+		if def.IsEnum() {
+			g.line("enum " + def.Name + ": String {")
+			g.indent++
+			for _, v := range def.Values {
+				if Camel(v) == v {
+					g.line("case " + v)
+				} else {
+					g.line("case " + Camel(v) + " = \"" + swiftLiteral(v) + "\"")
+				}
+			}
+			g.indent--
+			g.line("}")
+			g.line("")
+			continue
+		}
+		g.line("struct " + def.Name + ": Hashable {")
+		g.indent++
+		for _, f := range def.Fields {
+			g.line("let " + f.Name + ": " + swiftType(f.Type))
+		}
+		g.indent--
+		g.line("}")
+		g.line("")
+	}
+	g.line("struct " + swiftViewName(c) + ": View {")
+	g.indent++
+	for _, prop := range c.Props {
+		if prop.Default != nil {
+			g.line("var " + prop.Name + ": " + swiftType(prop.Type) + " = " + swiftDefault(c, prop))
+			continue
+		}
+		g.line("let " + prop.Name + ": " + swiftType(prop.Type))
+	}
+	for _, s := range c.Slots {
+		g.line("var " + slotName(s, "content") + ": AnyView? = nil")
+	}
+	if len(c.Props) > 0 || len(c.Slots) > 0 {
+		g.line("")
+	}
+	g.line("var body: some View {")
+	g.indent++
+	g.stack("VStack(alignment: .leading)", c.Ast.Root.Children, Scope{})
+	g.indent--
+	g.line("}")
+	g.indent--
+	g.line("}")
+	if len(g.diags) > 0 {
+		return "", g.diags
+	}
+	return g.out(), nil
+}
+
+// swiftViewName suffixes views with View, which also keeps components such as
+// Button from shadowing the SwiftUI view of the same name.
+func swiftViewName(c *Component) string {
+	if strings.HasSuffix(c.Name, "View") {
+		return c.Name
+	}
+	return c.Name + "View"
+}
+
+// swiftDefault renders the default of prop. Props with a default are vars,
+// since the memberwise initializer leaves out lets that have a value.
+func swiftDefault(c *Component, prop Field) string {
+	if c.IsEnum(prop.Type) {
+		return "." + Camel(prop.Default.(string))
+	}
+	return literal(prop.Default, func(s string) string {
+		return "\"" + swiftLiteral(s) + "\""
+	}, "[]")
+}
+
+func swiftType(t string) string {
+	if IsList(t) {
+		return "[" + swiftType(ListElem(t)) + "]"
+	}
+	switch t {
+	default:
+		{
+			return t
+		}
+	case "string":
+		{
+			return "String"
+		}
+	case "int":
+		{
+			return "Int"
+		}
+	case "float":
+		{
+			return "Double"
+		}
+	case "bool":
+		{
+			return "Bool"
+		}
+	}
+}
+
+type swiftGen struct {
+	lines
+	c     *Component
+	diags wirdiag.List
+}
+
+// stack writes nodes as a single view, wrapping them in container when there
+// is more than one.
+func (g *swiftGen) stack(container string, nodes []*wirparser.AstNode, scope Scope) {
+	switch len(nodes) {
+	default:
+		{
+			g.line(container + " {")
+			g.indent++
+			g.views(nodes, scope)
+			g.indent--
+			g.line("}")
+		}
+	case 0:
+		{
+			g.line("EmptyView()")
+		}
+	case 1:
+		{
+			g.view(nodes[0], scope)
+		}
+	}
+}
+
+func (g *swiftGen) views(nodes []*wirparser.AstNode, scope Scope) {
+	for _, n := range nodes {
+		g.view(n, scope)
+	}
+}
+
+func (g *swiftGen) view(n *wirparser.AstNode, scope Scope) {
+	switch n.Kind {
+	default:
+		{
+			g.views(n.Children, scope)
+		}
+	case wirparser.AstNodeKindString:
+		{
+			g.line("Text(" + swiftString(n.Children) + ")")
+		}
+	case wirparser.AstNodeKindForDirective:
+		{
+			g.line("ForEach(" + ForList(n) + ", id: \\.self) { " + n.Binding + " in")
+			g.indent++
+			g.views(n.Children, scope.With(n.Binding, n.BindingType))
+			g.indent--
+			g.line("}")
+		}
+	case wirparser.AstNodeKindIfDirective:
+		{
+			for i, branch := range n.Children {
+				switch {
+				default:
+					{
+						g.line("} else if " + branch.Value + " {")
+					}
+				case i == 0:
+					{
+						g.line("if " + branch.Value + " {")
+					}
+				case branch.Value == "":
+					{
+						g.line("} else {")
+					}
+				}
+				g.indent++
+				g.stack("VStack(alignment: .leading)", branch.Children, scope)
+				g.indent--
+			}
+			g.line("}")
+		}
+	case wirparser.AstNodeKindSwitchDirective:
+		{
+			g.match(n, scope)
+		}
+	case wirparser.AstNodeKindElement:
+		{
+			g.element(n, scope)
+		}
+	case wirparser.AstNodeKindComponent:
+		{
+			u, _ := g.c.Import(n.TagName)
+			var args []string
+			for _, arg := range g.c.Args(n, false) {
+				value := swiftDefault(u, Field{Type: arg.Prop.Type, Default: arg.Value})
+				if arg.Parts != nil {
+					value = swiftString(arg.Parts)
+				}
+				if part, ok := singleInterpolation(arg.Parts); ok {
+					value = part.Value
+				}
+				args = append(args, arg.Prop.Name+": "+value)
+			}
+			fills := g.c.Fills(n)
+			if len(fills) == 0 {
+				g.line(swiftViewName(u) + "(" + strings.Join(args, ", ") + ")")
+				return
+			}
+			g.line(swiftViewName(u) + "(")
+			g.indent++
+			for _, arg := range args {
+				g.line(arg + ",")
+			}
+			for i, fill := range fills {
+				g.line(slotName(fill.Slot, "content") + ": AnyView(")
+				g.indent++
+				g.stack("VStack(alignment: .leading)", fill.Children, scope)
+				g.indent--
+				if i < len(fills)-1 {
+					g.line("),")
+				} else {
+					g.line(")")
+				}
+			}
+			g.indent--
+			g.line(")")
+		}
+	case wirparser.AstNodeKindSlotDirective:
+		{
+			name := slotName(Slot{Name: n.Value}, "content")
+			g.line("if let " + name + " {")
+			g.indent++
+			g.line(name)
+			g.indent--
+			if len(n.Children) > 0 {
+				g.line("} else {")
+				g.indent++
+				g.stack("VStack(alignment: .leading)", n.Children, scope)
+				g.indent--
+			}
+			g.line("}")
+		}
+	}
+}
+
+// match writes a @switch as a switch statement. Swift switches must be
+// exhaustive, so one without a @default that leaves values unhandled gets an
+// empty one.
+func (g *swiftGen) match(n *wirparser.AstNode, scope Scope) {
+	t := InterpolationType(n)
+	isEnum := g.c.IsEnum(t)
+	g.line("switch " + n.Value + " {")
+	arms := switchArms(n)
+	for _, arm := range arms {
+		switch {
+		default:
+			{
+				g.line("case \"" + swiftLiteral(arm.Value) + "\":")
+			}
+		case arm.Kind == wirparser.AstNodeKindSwitchDefault:
+			{
+				g.line("default:")
+			}
+		case isEnum:
+			{
+				g.line("case ." + Camel(arm.Value) + ":")
+			}
+		}
+		g.indent++
+		g.stack("VStack(alignment: .leading)", arm.Children, scope)
+		g.indent--
+	}
+	if !coversSwitch(g.c, n) {
+		g.line("default:")
+		g.indent++
+		g.line("EmptyView()")
+		g.indent--
+	}
+	g.line("}")
+}
+
+func (g *swiftGen) element(n *wirparser.AstNode, scope Scope) {
+	if modifier, isText := swiftTextElements[n.TagName]; isText {
+		g.line("Text(" + g.text(n) + ")" + modifier)
+		return
+	}
+	switch n.TagName {
+	default:
+		{
+			g.diags = append(g.diags, g.c.Diag(n.Span, "<%s> has no SwiftUI mapping, expected one of h1, p, button, ul, li or div", n.TagName))
+		}
+	case "button":
+		{
+			if isTextOnly(n) {
+				g.line("Button(" + g.text(n) + ") {}")
+				return
+			}
+			g.line("Button(action: {}) {")
+			g.indent++
+			g.stack("VStack", n.Children, scope)
+			g.indent--
+			g.line("}")
+		}
+	case "ul":
+		{
+			g.line("List {")
+			g.indent++
+			g.views(n.Children, scope)
+			g.indent--
+			g.line("}")
+		}
+	case "li", "div":
+		{
+			g.stack("VStack(alignment: .leading)", n.Children, scope)
+		}
+	}
+}
+
+// text returns the string content of a text element, reporting any child
+// that is not a string.
+func (g *swiftGen) text(n *wirparser.AstNode) string {
+	var parts []*wirparser.AstNode
+	for _, child := range n.Children {
+		if child.Kind != wirparser.AstNodeKindString {
+			g.diags = append(g.diags, g.c.Diag(child.Span, "<%s> can only contain text in the swiftui target", n.TagName))
+			continue
+		}
+		parts = append(parts, child.Children...)
+	}
+	return swiftString(parts)
+}
+
+// isTextOnly reports whether n holds at most one string and nothing else.
+func isTextOnly(n *wirparser.AstNode) bool {
+	if len(n.Children) == 0 {
+		return true
+	}
+	return len(n.Children) == 1 && n.Children[0].Kind == wirparser.AstNodeKindString
+}
+
+// swiftString renders parts as a Swift string literal using \(...)
+// interpolation.
+func swiftString(parts []*wirparser.AstNode) string {
+	return "\"" + joinParts(parts, swiftLiteral, func(n *wirparser.AstNode) string {
+		return "\\(" + n.Value + ")"
+	}) + "\""
+}
+
+// swiftLiteral escapes s for use inside a Swift string literal.
+func swiftLiteral(s string) string {
+	return strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n").Replace(s)
+}
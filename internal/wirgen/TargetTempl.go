@@ -0,0 +1,326 @@
+package wirgen
+
+import (
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/phillip-england/wir/internal/wherr"
+	"github.com/phillip-england/wir/internal/wirparser"
+)
+
+// TargetTempl generates a templ component. Like the go target each component
+// gets its own package so the user types it declares can't collide. Its props
+// are positional parameters, so defaults declared by @props are left to the
+// caller, which for a component using another is the generated code. Slots
+// are templ.Component parameters following the props, where content is the
+// default slot, and the content a caller fills them with goes in a templ of
+// its own in the caller's file.
+type TargetTempl struct {
+	pkg    string
+	module string
+}
+
+func TargetTemplNew(opts TargetOptions) (Target, error) {
+	if opts.Package != "" && !isIdent(opts.Package) {
+		return nil, wherr.Err(wherr.Here(), "invalid Go package name %s", opts.Package)
+	}
+	return &TargetTempl{
+		pkg:    opts.Package,
+		module: opts.Module,
+	}, nil
+}
+
+func (t *TargetTempl) Ext() string {
+	return ".templ"
+}
+
+// Dir places each component in its own package directory so the user types
+// components declare, such as User, can't collide. Only a single file can be
+// built into a package named with --package.
+func (t *TargetTempl) Dir(c *Component) string {
+	return t.Package(c)
+}
+
+func (t *TargetTempl) Package(c *Component) string {
+	if t.pkg != "" {
+		return t.pkg
+	}
+	return strings.ToLower(c.Name)
+}
+
+func (t *TargetTempl) Generate(c *Component) (string, error) {
+	if diags := goCheckImports(c, t.module, t.pkg); len(diags) > 0 {
+		return "", diags
+	}
+	d := &templDialect{
+		c: c,
+	}
+	p := markupPrinterNew(d, 1)
+	p.unit = "\t"
+	p.nodes(c.Ast.Root.Children, Scope{})
+	var sb strings.Builder
+	sb.WriteString(header(c, "//", "") + "\n")
+	sb.WriteString("package " + t.Package(c) + "\n\n")
+	var imports []string
+	if d.usesStrconv {
+		imports = append(imports, "strconv")
+	}
+	for _, u := range c.Imports {
+		imports = append(imports, goImportPath(t.module, u, t.Package(u)))
+	}
+	if len(imports) == 1 {
+		sb.WriteString("import " + strconv.Quote(imports[0]) + "\n\n")
+	} else if len(imports) > 1 {
+		sb.WriteString("import (\n")
+		for _, imp := range imports {
+			sb.WriteString("\t" + strconv.Quote(imp) + "\n")
+		}
+		sb.WriteString(")\n\n")
+	}
+	for _, def := range c.Types {
+		if def.IsEnum() {
+			sb.WriteString(goEnum(def))
+			continue
+		}
+		sb.WriteString("type " + def.Name + " struct {\n")
+		for _, f := range def.Fields {
+			sb.WriteString("\t" + Pascal(f.Name) + " " + goType(f.Type) + "\n")
+		}
+		sb.WriteString("}\n\n")
+	}
+	var params []string
+	for _, prop := range c.Props {
+		params = append(params, goLocal(prop.Name)+" "+goType(prop.Type))
+	}
+	for _, s := range c.Slots {
+		params = append(params, goLocal(slotName(s, "content"))+" templ.Component")
+	}
+	sb.WriteString("templ " + c.Name + "(" + strings.Join(params, ", ") + ") {\n")
+	sb.WriteString(p.out())
+	sb.WriteString("}\n")
+	for _, fill := range d.fills {
+		sb.WriteString("\n" + fill)
+	}
+	return sb.String(), nil
+}
+
+type templDialect struct {
+	c           *Component
+	usesStrconv bool
+	// fills holds a templ for the content of each slot filled by the
+	// component, named in fillNames.
+	fills     []string
+	fillNames []string
+}
+
+// expr translates a wir expression into Go, converting values that aren't
+// strings since templ only renders strings.
+func (d *templDialect) expr(value string, scope Scope) string {
+	out := templPath(value)
+	switch d.c.ExprType(value, scope) {
+	default:
+		{
+			return out
+		}
+	case "int":
+		{
+			d.usesStrconv = true
+			return "strconv.Itoa(" + out + ")"
+		}
+	case "float":
+		{
+			d.usesStrconv = true
+			return "strconv.FormatFloat(" + out + ", 'f', -1, 64)"
+		}
+	case "bool":
+		{
+			d.usesStrconv = true
+			return "strconv.FormatBool(" + out + ")"
+		}
+	}
+}
+
+// templPath translates a wir expression such as user.name into Go.
+func templPath(value string) string {
+	parts := strings.Split(value, ".")
+	out := goLocal(parts[0])
+	for _, part := range parts[1:] {
+		out += "." + Pascal(part)
+	}
+	return out
+}
+
+func (d *templDialect) attr(a wirparser.AstAttr, scope Scope) string {
+	if a.IsBool() {
+		return " " + a.Key
+	}
+	if n, ok := singleInterpolation(a.Parts); ok && d.c.ExprType(n.Value, scope) == "bool" {
+		return " " + a.Key + "?={ " + templPath(n.Value) + " }"
+	}
+	var terms []string
+	hasExpr := false
+	for _, part := range a.Parts {
+		if part.Kind == wirparser.AstNodeKindInterpolation {
+			terms = append(terms, d.expr(part.Value, scope))
+			hasExpr = true
+			continue
+		}
+		terms = append(terms, strconv.Quote(part.Text))
+	}
+	if !hasExpr {
+		return " " + a.Key + "=\"" + html.EscapeString(joinParts(a.Parts, func(s string) string { return s }, nil)) + "\""
+	}
+	return " " + a.Key + "={ " + strings.Join(terms, " + ") + " }"
+}
+
+func (d *templDialect) text(n *wirparser.AstNode, scope Scope) string {
+	return joinParts(n.Children, templText, func(part *wirparser.AstNode) string {
+		return "{ " + d.expr(part.Value, scope) + " }"
+	})
+}
+
+// templText escapes static text, writing braces as string expressions so
+// templ doesn't read them as Go.
+func templText(s string) string {
+	return strings.NewReplacer("{", "{ \"{\" }", "}", "{ \"}\" }").Replace(html.EscapeString(s))
+}
+
+func (d *templDialect) loop(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	if usesBinding(n) {
+		p.line("for _, " + goLocal(n.Binding) + " := range " + goLocal(ForList(n)) + " {")
+	} else {
+		p.line("for range " + goLocal(ForList(n)) + " {")
+	}
+	p.indent++
+	p.nodes(n.Children, scope.With(n.Binding, n.BindingType))
+	p.indent--
+	p.line("}")
+}
+
+func (d *templDialect) cond(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	p.branches(n.Children, scope, func(i int, branch *wirparser.AstNode) string {
+		switch {
+		default:
+			{
+				return "} else if " + templPath(branch.Value) + " {"
+			}
+		case i == 0:
+			{
+				return "if " + templPath(branch.Value) + " {"
+			}
+		case branch.Value == "":
+			{
+				return "} else {"
+			}
+		}
+	}, "}")
+}
+
+func (d *templDialect) match(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	p.line("switch " + templPath(n.Value) + " {")
+	p.indent++
+	for _, arm := range switchArms(n) {
+		if arm.Kind == wirparser.AstNodeKindSwitchDefault {
+			p.line("default:")
+		} else {
+			p.line("case " + goCase(d.c, n, arm.Value) + ":")
+		}
+		p.indent++
+		p.nodes(arm.Children, scope)
+		p.indent--
+	}
+	p.indent--
+	p.line("}")
+}
+
+// component calls the templ of the package generated for the component,
+// passing every prop in order followed by a templ for each slot, which is nil
+// when the slot isn't filled.
+func (d *templDialect) component(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	u, _ := d.c.Import(n.TagName)
+	var args []string
+	for _, arg := range d.c.Args(n, true) {
+		args = append(args, goArg(arg, func(part *wirparser.AstNode) string {
+			return templPath(part.Value)
+		}, func(part *wirparser.AstNode) string {
+			return d.expr(part.Value, scope)
+		}))
+	}
+	fills := d.c.Fills(n)
+	for _, s := range u.Slots {
+		fill, filled := findFill(fills, s)
+		if !filled {
+			args = append(args, "nil")
+			continue
+		}
+		args = append(args, d.fill(n, fill, scope))
+	}
+	p.line("@" + strings.ToLower(n.TagName) + "." + n.TagName + "(" + strings.Join(args, ", ") + ")")
+}
+
+// fill adds a templ rendering the content of fill and returns the call
+// creating it. The templ is given every value the content reads from the one
+// it is passed from.
+func (d *templDialect) fill(n *wirparser.AstNode, fill Fill, scope Scope) string {
+	name := Camel(d.c.Name) + n.TagName + Pascal(slotName(fill.Slot, "content"))
+	for i := 2; containsStr(d.fillNames, name); i++ {
+		name = Camel(d.c.Name) + n.TagName + Pascal(slotName(fill.Slot, "content")) + strconv.Itoa(i)
+	}
+	d.fillNames = append(d.fillNames, name)
+	var params []string
+	var args []string
+	add := func(value string, t string) {
+		arg := goLocal(value)
+		if !containsStr(args, arg) {
+			args = append(args, arg)
+			params = append(params, arg+" "+t)
+		}
+	}
+	for _, child := range fill.Children {
+		for _, name := range freeVars(child, Scope{}) {
+			add(name, goType(d.valueType(name, scope)))
+		}
+		child.Walk(func(node *wirparser.AstNode) bool {
+			if node.Kind == wirparser.AstNodeKindSlotDirective {
+				add(slotName(Slot{Name: node.Value}, "content"), "templ.Component")
+			}
+			return true
+		})
+	}
+	p := markupPrinterNew(d, 1)
+	p.unit = "\t"
+	p.nodes(fill.Children, scope)
+	d.fills = append(d.fills, "templ "+name+"("+strings.Join(params, ", ")+") {\n"+p.out()+"}\n")
+	return name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// valueType is the type of the prop or @for binding name.
+func (d *templDialect) valueType(name string, scope Scope) string {
+	if t, bound := scope[name]; bound {
+		return t
+	}
+	p, _ := d.c.Prop(name)
+	return p.Type
+}
+
+// slot renders the templ passed for the slot, falling back to the children
+// of n when it is nil.
+func (d *templDialect) slot(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	name := goLocal(slotName(Slot{Name: n.Value}, "content"))
+	p.line("if " + name + " != nil {")
+	p.indent++
+	p.line("@" + name)
+	p.indent--
+	if len(n.Children) > 0 {
+		p.line("} else {")
+		p.indent++
+		p.nodes(n.Children, scope)
+		p.indent--
+	}
+	p.line("}")
+}
+
+func (d *templDialect) voidEnd() string {
+	return "/>"
+}
@@ -0,0 +1,202 @@
+package wirgen
+
+import (
+	"html"
+	"strings"
+
+	"github.com/phillip-england/wir/internal/wirparser"
+)
+
+// TargetVue generates a Vue single file component using <script setup>.
+type TargetVue struct{}
+
+func TargetVueNew(opts TargetOptions) (Target, error) {
+	return &TargetVue{}, nil
+}
+
+func (t *TargetVue) Ext() string {
+	return ".vue"
+}
+
+func (t *TargetVue) Generate(c *Component) (string, error) {
+	p := markupPrinterNew(&vueDialect{
+		c: c,
+	}, 1)
+	p.nodes(c.Ast.Root.Children, Scope{})
+	var sb strings.Builder
+	sb.WriteString(header(c, "<!--", " -->"))
+	interfaces := tsInterfaces(c, "", false, "")
+	if interfaces != "" || len(c.Imports) > 0 {
+		sb.WriteString("<script setup lang=\"ts\">\n")
+		sb.WriteString(jsImports(c, ".vue"))
+		if interfaces != "" && len(c.Imports) > 0 {
+			sb.WriteString("\n")
+		}
+		if interfaces != "" {
+			sb.WriteString(interfaces + "\n")
+			sb.WriteString(vueDefineProps(c))
+		}
+		sb.WriteString("</script>\n\n")
+	}
+	sb.WriteString("<template>\n")
+	sb.WriteString(p.out())
+	sb.WriteString("</template>\n")
+	return sb.String(), nil
+}
+
+// vueDefineProps declares the props, passing their defaults to withDefaults.
+// Vue shares a default object or array between instances unless a factory
+// returns it, so lists default through one.
+func vueDefineProps(c *Component) string {
+	if !c.HasDefaults() {
+		return "defineProps<Props>();\n"
+	}
+	out := "withDefaults(defineProps<Props>(), {\n"
+	for _, prop := range c.Props {
+		switch {
+		default:
+			{
+				out += "  " + prop.Name + ": " + jsLiteral(prop.Default) + ",\n"
+			}
+		case prop.Default == nil:
+			{
+				continue
+			}
+		case IsList(prop.Type):
+			{
+				out += "  " + prop.Name + ": () => [],\n"
+			}
+		}
+	}
+	return out + "});\n"
+}
+
+type vueDialect struct {
+	c *Component
+}
+
+// attr binds attributes holding interpolations with :key, using a template
+// literal when static text and interpolations are mixed.
+func (d *vueDialect) attr(a wirparser.AstAttr, scope Scope) string {
+	if a.IsBool() {
+		return " " + a.Key
+	}
+	if n, ok := singleInterpolation(a.Parts); ok {
+		return " :" + a.Key + "=\"" + n.Value + "\""
+	}
+	value, hasExpr := templateLiteral(a.Parts)
+	if hasExpr {
+		return " :" + a.Key + "=\"" + value + "\""
+	}
+	return " " + a.Key + "=\"" + html.EscapeString(joinParts(a.Parts, func(s string) string { return s }, nil)) + "\""
+}
+
+// templateLiteral renders attribute parts as a JavaScript template literal
+// that is safe inside a double quoted html attribute. It also reports whether
+// any of the parts are interpolations.
+func templateLiteral(parts []*wirparser.AstNode) (string, bool) {
+	hasExpr := false
+	out := joinParts(parts, func(s string) string {
+		return html.EscapeString(jsTemplate(s))
+	}, func(n *wirparser.AstNode) string {
+		hasExpr = true
+		return "${" + n.Value + "}"
+	})
+	return "`" + out + "`", hasExpr
+}
+
+func (d *vueDialect) text(n *wirparser.AstNode, scope Scope) string {
+	return joinParts(n.Children, braceSafeText, func(part *wirparser.AstNode) string {
+		return "{{ " + part.Value + " }}"
+	})
+}
+
+// loop puts v-for on the body when it is a single element and on a wrapping
+// <template> otherwise.
+func (d *vueDialect) loop(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	index := n.Binding + "Index"
+	directive := " v-for=\"(" + n.Binding + ", " + index + ") in " + ForList(n) + "\" :key=\"" + index + "\""
+	inner := scope.With(n.Binding, n.BindingType)
+	if len(n.Children) == 1 && n.Children[0].Kind == wirparser.AstNodeKindElement {
+		p.element(n.Children[0], inner, directive)
+		return
+	}
+	p.line("<template" + directive + ">")
+	p.indent++
+	p.nodes(n.Children, inner)
+	p.indent--
+	p.line("</template>")
+}
+
+// cond puts v-if, v-else-if and v-else on the body of each branch when it is
+// a single element and on a wrapping <template> otherwise.
+func (d *vueDialect) cond(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	for i, branch := range n.Children {
+		directive := " v-else-if=\"" + branch.Value + "\""
+		if i == 0 {
+			directive = " v-if=\"" + branch.Value + "\""
+		} else if branch.Value == "" {
+			directive = " v-else"
+		}
+		if len(branch.Children) == 1 && branch.Children[0].Kind == wirparser.AstNodeKindElement {
+			p.element(branch.Children[0], scope, directive)
+			continue
+		}
+		p.line("<template" + directive + ">")
+		p.indent++
+		p.nodes(branch.Children, scope)
+		p.indent--
+		p.line("</template>")
+	}
+}
+
+// match lowers a @switch to a v-if chain. The literals are single quoted as
+// they sit inside a double quoted attribute.
+func (d *vueDialect) match(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	d.cond(p, switchAsIf(n, func(lit string) string {
+		lit = strings.NewReplacer("\\", "\\\\", "'", "\\'", "\"", "&quot;").Replace(lit)
+		return n.Value + " === '" + lit + "'"
+	}), scope)
+}
+
+// component binds the props passed as values with :key, leaving static
+// strings and a bare true as plain attributes.
+func (d *vueDialect) component(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	out := "<" + n.TagName
+	for _, arg := range d.c.Args(n, false) {
+		s, isString := arg.Value.(string)
+		switch {
+		default:
+			{
+				out += " :" + arg.Prop.Name + "=\"" + jsLiteral(arg.Value) + "\""
+			}
+		case arg.Parts != nil:
+			{
+				out += d.attr(wirparser.AstAttr{Key: arg.Prop.Name, Parts: arg.Parts}, scope)
+			}
+		case arg.Value == true:
+			{
+				out += " " + arg.Prop.Name
+			}
+		case isString:
+			{
+				out += " " + arg.Prop.Name + "=\"" + html.EscapeString(s) + "\""
+			}
+		}
+	}
+	p.use(out, "</"+n.TagName+">", " />", d.c.Fills(n), scope, func(fill Fill) {
+		p.line("<template #" + fill.Slot.Name + ">")
+		p.indent++
+		p.nodes(fill.Children, scope)
+		p.indent--
+		p.line("</template>")
+	})
+}
+
+func (d *vueDialect) slot(p *markupPrinter, n *wirparser.AstNode, scope Scope) {
+	p.element(slotElement(n), scope, "")
+}
+
+func (d *vueDialect) voidEnd() string {
+	return " />"
+}
@@ -0,0 +1,485 @@
+package wirgen
+
+import (
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/phillip-england/wir/internal/wirparser"
+)
+
+// TargetWebComponent generates a framework free custom element. Its template
+// is rendered into a shadow root once, after which each prop setter only
+// re-renders the elements that read that prop. Imported components are used
+// as their custom elements, passing props as attributes and slot content as
+// children assigned to the element's native slots.
+type TargetWebComponent struct{}
+
+func TargetWebComponentNew(opts TargetOptions) (Target, error) {
+	return &TargetWebComponent{}, nil
+}
+
+func (t *TargetWebComponent) Ext() string {
+	return ".js"
+}
+
+func (t *TargetWebComponent) Generate(c *Component) (string, error) {
+	g := &wcGen{
+		c:    c,
+		deps: map[string][]int{},
+	}
+	// root nodes other than elements are drawn again by render() when a prop
+	// they read changes
+	tmpl := ""
+	for _, n := range c.Ast.Root.Children {
+		if n.Kind == wirparser.AstNodeKindElement {
+			tmpl += g.node(n, Scope{}, false)
+			continue
+		}
+		tmpl += g.node(n, Scope{}, true)
+		g.rootDeps = append(g.rootDeps, freeVars(n, Scope{})...)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(header(c, "//", "") + "\n")
+	for _, u := range c.Imports {
+		sb.WriteString("import " + strconv.Quote(importPath(c, u, ".js")) + ";\n")
+	}
+	if len(c.Imports) > 0 {
+		sb.WriteString("\n")
+	}
+	if g.usesEscape {
+		sb.WriteString("const escapeHtml = (value) =>\n")
+		sb.WriteString("  String(value).replace(/[&<>\"']/g, (ch) => ({ \"&\": \"&amp;\", \"<\": \"&lt;\", \">\": \"&gt;\", '\"': \"&quot;\", \"'\": \"&#39;\" })[ch]);\n\n")
+	}
+	sb.WriteString("export class " + c.Name + " extends HTMLElement {\n")
+	if len(c.Props) > 0 {
+		var attrs []string
+		for _, prop := range c.Props {
+			attrs = append(attrs, strconv.Quote(wcAttrName(prop.Name)))
+		}
+		sb.WriteString("  static observedAttributes = [" + strings.Join(attrs, ", ") + "];\n\n")
+		for _, prop := range c.Props {
+			if prop.Default != nil {
+				sb.WriteString("  #" + prop.Name + " = " + jsLiteral(prop.Default) + ";\n")
+				continue
+			}
+			sb.WriteString("  #" + prop.Name + " = " + wcDefault(wcType(c, prop.Type)) + ";\n")
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("  constructor() {\n")
+	sb.WriteString("    super();\n")
+	sb.WriteString("    this.attachShadow({ mode: \"open\" });\n")
+	sb.WriteString("  }\n\n")
+	sb.WriteString("  connectedCallback() {\n")
+	sb.WriteString("    this.render();\n")
+	sb.WriteString("  }\n\n")
+	if len(c.Props) > 0 {
+		sb.WriteString("  attributeChangedCallback(name, oldValue, newValue) {\n")
+		sb.WriteString("    switch (name) {\n")
+		for _, prop := range c.Props {
+			sb.WriteString("      case " + strconv.Quote(wcAttrName(prop.Name)) + ":\n")
+			sb.WriteString("        this." + prop.Name + " = " + wcFromAttr(wcType(c, prop.Type)) + ";\n")
+			sb.WriteString("        break;\n")
+		}
+		sb.WriteString("    }\n")
+		sb.WriteString("  }\n\n")
+		for _, prop := range c.Props {
+			sb.WriteString("  get " + prop.Name + "() {\n")
+			sb.WriteString("    return this.#" + prop.Name + ";\n")
+			sb.WriteString("  }\n\n")
+			sb.WriteString("  set " + prop.Name + "(value) {\n")
+			sb.WriteString("    this.#" + prop.Name + " = value;\n")
+			sb.WriteString("    if (!this.isConnected) {\n")
+			sb.WriteString("      return;\n")
+			sb.WriteString("    }\n")
+			if containsStr(g.rootDeps, prop.Name) {
+				sb.WriteString("    this.render();\n")
+			} else {
+				for _, k := range g.deps[prop.Name] {
+					sb.WriteString("    this.#update" + strconv.Itoa(k) + "();\n")
+				}
+			}
+			sb.WriteString("  }\n\n")
+		}
+	}
+	sb.WriteString("  render() {\n")
+	sb.WriteString("    this.shadowRoot.innerHTML = `" + tmpl + "`;\n")
+	sb.WriteString("  }\n")
+	for i, update := range g.updates {
+		sb.WriteString("\n  #update" + strconv.Itoa(i) + "() {\n")
+		sb.WriteString("    const el = this.shadowRoot.querySelector('[data-wir=\"" + strconv.Itoa(update.marker) + "\"]');\n")
+		sb.WriteString("    if (!el) {\n")
+		sb.WriteString("      return;\n")
+		sb.WriteString("    }\n")
+		sb.WriteString("    " + update.stmt + "\n")
+		sb.WriteString("  }\n")
+	}
+	sb.WriteString("}\n\n")
+	sb.WriteString("customElements.define(" + strconv.Quote(customElementName(c)) + ", " + c.Name + ");\n")
+	return sb.String(), nil
+}
+
+type wcGen struct {
+	c          *Component
+	markers    int
+	updates    []wcUpdate
+	deps       map[string][]int
+	rootDeps   []string
+	usesEscape bool
+}
+
+// wcUpdate is a statement refreshing part of the element marked with
+// data-wir="marker".
+type wcUpdate struct {
+	marker int
+	stmt   string
+}
+
+// nodes renders nodes as the body of a JavaScript template literal. Elements
+// that read props are marked with data-wir so their update method can find
+// them again, unless they are redrawn whole by the update of what holds them,
+// as the body of a loop or a conditional is.
+func (g *wcGen) nodes(nodes []*wirparser.AstNode, scope Scope, redrawn bool) string {
+	out := ""
+	for _, n := range nodes {
+		out += g.node(n, scope, redrawn)
+	}
+	return out
+}
+
+func (g *wcGen) node(n *wirparser.AstNode, scope Scope, redrawn bool) string {
+	switch n.Kind {
+	default:
+		{
+			return g.nodes(n.Children, scope, redrawn)
+		}
+	case wirparser.AstNodeKindString:
+		{
+			return joinParts(n.Children, wcText, func(part *wirparser.AstNode) string {
+				return g.escaped(part.Value, scope)
+			})
+		}
+	case wirparser.AstNodeKindForDirective:
+		{
+			inner := scope.With(n.Binding, n.BindingType)
+			body := g.nodes(n.Children, inner, true)
+			return "${" + wcExpr(ForList(n), scope) + ".map((" + n.Binding + ") => `" + body + "`).join(\"\")}"
+		}
+	case wirparser.AstNodeKindIfDirective:
+		{
+			out := ""
+			for _, branch := range n.Children {
+				body := "`" + g.nodes(branch.Children, scope, true) + "`"
+				if branch.Value == "" {
+					return "${" + out + body + "}"
+				}
+				out += wcExpr(branch.Value, scope) + " ? " + body + " : "
+			}
+			return "${" + out + "\"\"}"
+		}
+	case wirparser.AstNodeKindSwitchDirective:
+		{
+			value := wcExpr(n.Value, scope)
+			out := ""
+			for _, arm := range switchArms(n) {
+				body := "`" + g.nodes(arm.Children, scope, true) + "`"
+				if arm.Kind == wirparser.AstNodeKindSwitchDefault {
+					return "${" + out + body + "}"
+				}
+				out += value + " === " + strconv.Quote(arm.Value) + " ? " + body + " : "
+			}
+			return "${" + out + "\"\"}"
+		}
+	case wirparser.AstNodeKindElement:
+		{
+			return g.element(n, scope, redrawn)
+		}
+	case wirparser.AstNodeKindComponent:
+		{
+			return g.use(n, scope, redrawn)
+		}
+	case wirparser.AstNodeKindSlotDirective:
+		{
+			return g.element(slotElement(n), scope, redrawn)
+		}
+	}
+}
+
+// use renders the custom element of an imported component. A bool prop is
+// passed by the presence of its attribute, so one passed a value is toggled.
+// Text passed to the default slot is updated like the content of an element.
+func (g *wcGen) use(n *wirparser.AstNode, scope Scope, redrawn bool) string {
+	u, _ := g.c.Import(n.TagName)
+	tag := customElementName(u)
+	fills := g.c.Fills(n)
+	var contentDeps []string
+	for _, fill := range fills {
+		if fill.Slot.Name != "" {
+			continue
+		}
+		for _, child := range nonElements(fill.Children) {
+			contentDeps = append(contentDeps, freeVars(child, scope)...)
+		}
+	}
+	k := -1
+	if !redrawn && len(contentDeps)+len(freeVarsOfAttrs(n.Attrs, scope)) > 0 {
+		k = g.markers
+		g.markers++
+	}
+	open := "<" + tag
+	if k >= 0 {
+		open += " data-wir=\"" + strconv.Itoa(k) + "\""
+	}
+	for _, arg := range g.c.Args(n, false) {
+		key := wcAttrName(arg.Prop.Name)
+		if arg.Parts == nil {
+			if arg.Value == true {
+				open += " " + key
+			} else if arg.Value != false {
+				open += " " + key + "=\"" + wcText(literal(arg.Value, func(s string) string { return s }, "")) + "\""
+			}
+			continue
+		}
+		deps := freeVarsOfParts(arg.Parts, scope)
+		if part, ok := singleInterpolation(arg.Parts); ok && arg.Prop.Type == "bool" {
+			value := wcExpr(part.Value, scope)
+			open += "${" + value + " ? \" " + key + "\" : \"\"}"
+			if k >= 0 {
+				g.record(k, "el.toggleAttribute("+strconv.Quote(key)+", "+value+");", deps)
+			}
+			continue
+		}
+		open += " " + key + "=\"" + joinParts(arg.Parts, wcText, func(part *wirparser.AstNode) string {
+			return g.escaped(part.Value, scope)
+		}) + "\""
+		if k >= 0 {
+			value := joinParts(arg.Parts, jsTemplate, func(part *wirparser.AstNode) string {
+				return "${" + wcExpr(part.Value, scope) + "}"
+			})
+			g.record(k, "el.setAttribute("+strconv.Quote(key)+", `"+value+"`);", deps)
+		}
+	}
+	children := ""
+	for _, fill := range fills {
+		if fill.Slot.Name == "" {
+			children += g.nodes(fill.Children, scope, redrawn)
+			continue
+		}
+		children += g.element(slotted(fill), scope, redrawn)
+	}
+	if k >= 0 && len(contentDeps) > 0 {
+		g.record(k, "el.innerHTML = `"+children+"`;", contentDeps)
+	}
+	return open + ">" + children + "</" + tag + ">"
+}
+
+func (g *wcGen) element(n *wirparser.AstNode, scope Scope, redrawn bool) string {
+	var contentDeps []string
+	for _, child := range nonElements(n.Children) {
+		contentDeps = append(contentDeps, freeVars(child, scope)...)
+	}
+	k := -1
+	if !redrawn && len(contentDeps)+len(freeVarsOfAttrs(n.Attrs, scope)) > 0 {
+		k = g.markers
+		g.markers++
+	}
+	open := "<" + n.TagName
+	if k >= 0 {
+		open += " data-wir=\"" + strconv.Itoa(k) + "\""
+	}
+	for _, attr := range n.Attrs {
+		open += " " + attr.Key
+		if attr.IsBool() {
+			continue
+		}
+		open += "=\"" + joinParts(attr.Parts, wcText, func(part *wirparser.AstNode) string {
+			return g.escaped(part.Value, scope)
+		}) + "\""
+		deps := freeVarsOfParts(attr.Parts, scope)
+		if k >= 0 && len(deps) > 0 {
+			value := joinParts(attr.Parts, jsTemplate, func(part *wirparser.AstNode) string {
+				return "${" + wcExpr(part.Value, scope) + "}"
+			})
+			g.record(k, "el.setAttribute("+strconv.Quote(attr.Key)+", `"+value+"`);", deps)
+		}
+	}
+	open += ">"
+	if isVoidElement(n.TagName) {
+		return open
+	}
+	children := g.nodes(n.Children, scope, redrawn)
+	if k >= 0 && len(contentDeps) > 0 {
+		g.record(k, "el.innerHTML = `"+children+"`;", contentDeps)
+	}
+	return open + children + "</" + n.TagName + ">"
+}
+
+// record adds an update of the element marked k that runs when any of deps
+// change.
+func (g *wcGen) record(k int, stmt string, deps []string) {
+	i := len(g.updates)
+	g.updates = append(g.updates, wcUpdate{
+		marker: k,
+		stmt:   stmt,
+	})
+	sort.Strings(deps)
+	for j, dep := range deps {
+		if j > 0 && deps[j-1] == dep {
+			continue
+		}
+		g.deps[dep] = append(g.deps[dep], i)
+	}
+}
+
+func (g *wcGen) escaped(value string, scope Scope) string {
+	g.usesEscape = true
+	return "${escapeHtml(" + wcExpr(value, scope) + ")}"
+}
+
+// wcExpr reads props from the element and @for bindings directly.
+func wcExpr(value string, scope Scope) string {
+	if scope.Has(strings.Split(value, ".")[0]) {
+		return value
+	}
+	return "this." + value
+}
+
+func wcText(s string) string {
+	return jsTemplate(html.EscapeString(s))
+}
+
+// wcAttrName converts a prop name to its kebab case attribute.
+func wcAttrName(name string) string {
+	return strings.ReplaceAll(Snake(name), "_", "-")
+}
+
+// wcType treats the values of enums as the strings they are.
+func wcType(c *Component, t string) string {
+	if c.IsEnum(t) {
+		return "string"
+	}
+	return t
+}
+
+func wcDefault(t string) string {
+	switch {
+	default:
+		{
+			return "null"
+		}
+	case IsList(t):
+		{
+			return "[]"
+		}
+	case t == "string":
+		{
+			return "\"\""
+		}
+	case t == "int" || t == "float":
+		{
+			return "0"
+		}
+	case t == "bool":
+		{
+			return "false"
+		}
+	}
+}
+
+// wcFromAttr converts the string value of an attribute to the prop type.
+// Lists are passed as JSON.
+func wcFromAttr(t string) string {
+	switch {
+	default:
+		{
+			return "JSON.parse(newValue ?? \"null\")"
+		}
+	case IsList(t):
+		{
+			return "JSON.parse(newValue ?? \"[]\")"
+		}
+	case t == "string":
+		{
+			return "newValue ?? \"\""
+		}
+	case t == "int" || t == "float":
+		{
+			return "Number(newValue)"
+		}
+	case t == "bool":
+		{
+			return "newValue !== null"
+		}
+	}
+}
+
+// freeVars lists the props read anywhere beneath n.
+func freeVars(n *wirparser.AstNode, scope Scope) []string {
+	var out []string
+	switch n.Kind {
+	case wirparser.AstNodeKindInterpolation:
+		{
+			root := strings.Split(n.Value, ".")[0]
+			if !scope.Has(root) {
+				out = append(out, root)
+			}
+		}
+	case wirparser.AstNodeKindIfBranch, wirparser.AstNodeKindSwitchDirective:
+		{
+			root := strings.Split(n.Value, ".")[0]
+			if n.Value != "" && !scope.Has(root) {
+				out = append(out, root)
+			}
+		}
+	case wirparser.AstNodeKindForDirective:
+		{
+			if !scope.Has(ForList(n)) {
+				out = append(out, ForList(n))
+			}
+			scope = scope.With(n.Binding, n.BindingType)
+		}
+	}
+	out = append(out, freeVarsOfAttrs(n.Attrs, scope)...)
+	for _, child := range n.Children {
+		out = append(out, freeVars(child, scope)...)
+	}
+	return out
+}
+
+func freeVarsOfAttrs(attrs []wirparser.AstAttr, scope Scope) []string {
+	var out []string
+	for _, attr := range attrs {
+		out = append(out, freeVarsOfParts(attr.Parts, scope)...)
+	}
+	return out
+}
+
+func freeVarsOfParts(parts []*wirparser.AstNode, scope Scope) []string {
+	var out []string
+	for _, part := range parts {
+		out = append(out, freeVars(part, scope)...)
+	}
+	return out
+}
+
+func nonElements(nodes []*wirparser.AstNode) []*wirparser.AstNode {
+	var out []*wirparser.AstNode
+	for _, n := range nodes {
+		if n.Kind != wirparser.AstNodeKindElement {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func containsStr(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
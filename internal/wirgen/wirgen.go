@@ -0,0 +1,330 @@
+package wirgen
+
+import (
+	"html"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var primitiveTypes = []string{"string", "int", "float", "bool"}
+
+func IsPrimitive(t string) bool {
+	for _, p := range primitiveTypes {
+		if p == t {
+			return true
+		}
+	}
+	return false
+}
+
+func IsList(t string) bool {
+	return strings.HasPrefix(t, "[]")
+}
+
+// ListElem returns the element type of a list type such as []User.
+func ListElem(t string) string {
+	return strings.TrimPrefix(t, "[]")
+}
+
+// Pascal converts snake, kebab or camel case names to PascalCase.
+func Pascal(s string) string {
+	out := ""
+	upper := true
+	for _, r := range s {
+		if r == '_' || r == '-' || r == ' ' || r == '.' {
+			upper = true
+			continue
+		}
+		if upper {
+			out += string(unicode.ToUpper(r))
+			upper = false
+			continue
+		}
+		out += string(r)
+	}
+	return out
+}
+
+// Camel converts a name to camelCase.
+func Camel(s string) string {
+	p := []rune(Pascal(s))
+	if len(p) == 0 {
+		return ""
+	}
+	p[0] = unicode.ToLower(p[0])
+	return string(p)
+}
+
+// Snake converts a camelCase or PascalCase name to snake_case.
+func Snake(s string) string {
+	out := ""
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				out += "_"
+			}
+			out += string(unicode.ToLower(r))
+			continue
+		}
+		out += string(r)
+	}
+	return out
+}
+
+// Plural names the collection a @for binding iterates, so @for(user: User)
+// loops over users.
+func Plural(s string) string {
+	switch {
+	default:
+		return s + "s"
+	case strings.HasSuffix(s, "s"), strings.HasSuffix(s, "x"), strings.HasSuffix(s, "ch"), strings.HasSuffix(s, "sh"):
+		return s + "es"
+	case strings.HasSuffix(s, "y") && len(s) > 1 && !strings.ContainsAny(s[len(s)-2:len(s)-1], "aeiou"):
+		return s[:len(s)-1] + "ies"
+	}
+}
+
+// ComponentName derives a component name from the path of its .wir file.
+func ComponentName(p string) string {
+	base := path.Base(p)
+	return Pascal(strings.TrimSuffix(base, path.Ext(base)))
+}
+
+// isIdent reports whether s is a plain identifier that is safe to emit in
+// every target language.
+func isIdent(s string) bool {
+	for i, r := range s {
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r == '_'
+		if i == 0 && !isLetter {
+			return false
+		}
+		if !isLetter && !(r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// tsType maps a wir type to TypeScript.
+func tsType(t string) string {
+	if IsList(t) {
+		return tsType(ListElem(t)) + "[]"
+	}
+	switch t {
+	default:
+		{
+			return t
+		}
+	case "int", "float":
+		{
+			return "number"
+		}
+	case "bool":
+		{
+			return "boolean"
+		}
+	}
+}
+
+// tsInterfaces declares an interface for every user type of c followed by
+// its Props, indenting each line by pad. Components without props get none.
+// Single file components can't export from their script, so exporting is
+// left to the caller. Targets passing slot content as props give slotType,
+// which adds an optional prop of that type per slot.
+func tsInterfaces(c *Component, pad string, isExported bool, slotType string) string {
+	slots := ""
+	for _, s := range c.Slots {
+		if slotType != "" {
+			slots += pad + "  " + slotName(s, "children") + "?: " + slotType + ";\n"
+		}
+	}
+	if len(c.Props) == 0 && slots == "" {
+		return ""
+	}
+	out := ""
+	for _, def := range c.Types {
+		out += tsTypeDef(def, pad, isExported) + "\n"
+	}
+	props := tsInterface("Props", c.Props, pad, isExported)
+	return out + strings.TrimSuffix(props, pad+"}\n") + slots + pad + "}\n"
+}
+
+// defaultSlotLast orders slots so that the default slot comes last, where
+// Kotlin and Dart expect the child content of a widget.
+func defaultSlotLast(slots []Slot) []Slot {
+	var out []Slot
+	var content []Slot
+	for _, s := range slots {
+		if s.Name == "" {
+			content = append(content, s)
+			continue
+		}
+		out = append(out, s)
+	}
+	return append(out, content...)
+}
+
+// defaultSlotNames are the fallbacks targets pass to slotName for the
+// default slot, which no prop or named slot may take from it.
+var defaultSlotNames = []string{"children", "content", "child", "slot"}
+
+// slotName is the name a slot is passed under in targets that pass slot
+// content like a prop, which for the default slot is fallback.
+func slotName(s Slot, fallback string) string {
+	if s.Name == "" {
+		return fallback
+	}
+	return s.Name
+}
+
+// tsTypeDef declares a user type, spelling an enum as a union of its values.
+func tsTypeDef(def *TypeDef, pad string, isExported bool) string {
+	if !def.IsEnum() {
+		return tsInterface(def.Name, def.Fields, pad, isExported)
+	}
+	var values []string
+	for _, v := range def.Values {
+		values = append(values, strconv.Quote(v))
+	}
+	decl := "type " + def.Name + " = " + strings.Join(values, " | ") + ";\n"
+	if isExported {
+		return pad + "export " + decl
+	}
+	return pad + decl
+}
+
+func tsInterface(name string, fields []Field, pad string, isExported bool) string {
+	out := pad + "interface " + name + " {\n"
+	if isExported {
+		out = pad + "export interface " + name + " {\n"
+	}
+	for _, f := range fields {
+		out += pad + "  " + f.Name + tsOptional(f) + ": " + tsType(f.Type) + ";\n"
+	}
+	return out + pad + "}\n"
+}
+
+// tsOptional marks a prop with a default as optional.
+func tsOptional(f Field) string {
+	if f.Default != nil {
+		return "?"
+	}
+	return ""
+}
+
+// literal renders the default of a prop, quoting strings with quote and
+// writing an empty list as list. Enum defaults are strings, so targets with a
+// native enum type handle those themselves.
+func literal(v any, quote func(s string) string, list string) string {
+	switch v := v.(type) {
+	default:
+		{
+			return list
+		}
+	case string:
+		{
+			return quote(v)
+		}
+	case float64:
+		{
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	case bool:
+		{
+			return strconv.FormatBool(v)
+		}
+	}
+}
+
+// jsLiteral renders the default of a prop in JavaScript.
+func jsLiteral(v any) string {
+	return literal(v, strconv.Quote, "[]")
+}
+
+// jsTemplate escapes s for use inside a JavaScript template literal.
+func jsTemplate(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	s = strings.ReplaceAll(s, "`", "\\`")
+	return strings.ReplaceAll(s, "${", "\\${")
+}
+
+// header is the generated file comment, wrapped in the comment syntax of a
+// target.
+func header(c *Component, open string, close string) string {
+	return open + " Code generated by wir from " + path.Base(c.Path) + ". DO NOT EDIT." + close + "\n"
+}
+
+// wirString quotes s the way a string is written in a .wir file.
+func wirString(s string) string {
+	return "'" + strings.NewReplacer("\\", "\\\\", "'", "\\'").Replace(s) + "'"
+}
+
+// contextComment documents the props of c and their defaults, the fields of
+// the types they use and the values of its enums in wir's own type syntax,
+// for templates that have no way to declare them.
+func contextComment(c *Component, open string, close string) string {
+	if len(c.Props) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(open + "\n  Context:\n")
+	for _, prop := range c.Props {
+		sb.WriteString("    " + prop.Name + ": " + prop.Type)
+		if prop.Default != nil {
+			sb.WriteString(" = " + literal(prop.Default, wirString, "[]"))
+		}
+		sb.WriteString("\n")
+	}
+	for _, def := range c.Types {
+		if def.IsEnum() {
+			sb.WriteString("\n  " + def.Name + ": '" + strings.Join(def.Values, "' | '") + "'\n")
+			continue
+		}
+		sb.WriteString("\n  " + def.Name + ":\n")
+		for _, f := range def.Fields {
+			sb.WriteString("    " + f.Name + ": " + f.Type + "\n")
+		}
+	}
+	sb.WriteString(close + "\n")
+	return sb.String()
+}
+
+// braceSafeText escapes static text for templates delimited by braces,
+// writing { as an entity so it can't open a tag or expression.
+func braceSafeText(s string) string {
+	return strings.ReplaceAll(html.EscapeString(s), "{", "&#123;")
+}
+
+// importPath is the path c imports the output of u from, relative to the
+// output of c and ending in ext.
+func importPath(c *Component, u *Component, ext string) string {
+	dir, err := filepath.Rel(path.Dir(c.Path), path.Dir(u.Path))
+	if err != nil {
+		dir = "."
+	}
+	p := path.Join(filepath.ToSlash(dir), strings.TrimSuffix(path.Base(u.Path), path.Ext(u.Path))+ext)
+	if strings.HasPrefix(p, "../") {
+		return p
+	}
+	return "./" + p
+}
+
+// templateName names the template generated for u the way template engines
+// that look templates up from a root directory include it, by its path from
+// the root of the project.
+func templateName(u *Component, ext string) string {
+	return strings.TrimSuffix(u.RelPath, path.Ext(u.RelPath)) + ext
+}
+
+// customElementName derives the custom element tag for c. Custom element names
+// need a hyphen, so single word components are prefixed with wir-.
+func customElementName(c *Component) string {
+	name := strings.ReplaceAll(Snake(c.Name), "_", "-")
+	if !strings.Contains(name, "-") {
+		return "wir-" + name
+	}
+	return name
+}
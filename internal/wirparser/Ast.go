@@ -0,0 +1,128 @@
+package wirparser
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/phillip-england/wir/internal/wherr"
+	"github.com/phillip-england/wir/internal/wirtokenizer"
+)
+
+type AstNodeKind string
+
+const (
+	AstNodeKindRoot            AstNodeKind = "ROOT"
+	AstNodeKindElement         AstNodeKind = "ELEMENT"
+	AstNodeKindString          AstNodeKind = "STRING"
+	AstNodeKindText            AstNodeKind = "TEXT"
+	AstNodeKindInterpolation   AstNodeKind = "INTERPOLATION"
+	AstNodeKindForDirective    AstNodeKind = "FOR_DIRECTIVE"
+	AstNodeKindIfDirective     AstNodeKind = "IF_DIRECTIVE"
+	AstNodeKindIfBranch        AstNodeKind = "IF_BRANCH"
+	AstNodeKindSwitchDirective AstNodeKind = "SWITCH_DIRECTIVE"
+	AstNodeKindSwitchCase      AstNodeKind = "SWITCH_CASE"
+	AstNodeKindSwitchDefault   AstNodeKind = "SWITCH_DEFAULT"
+	AstNodeKindPropsDirective  AstNodeKind = "PROPS_DIRECTIVE"
+	AstNodeKindProp            AstNodeKind = "PROP"
+	AstNodeKindImportDirective AstNodeKind = "IMPORT_DIRECTIVE"
+	AstNodeKindComponent       AstNodeKind = "COMPONENT"
+	AstNodeKindSlotDirective   AstNodeKind = "SLOT_DIRECTIVE"
+	AstNodeKindFillDirective   AstNodeKind = "FILL_DIRECTIVE"
+)
+
+// AstNode is a single node in a parsed .wir tree. Which fields are populated
+// depends on Kind:
+//
+//	ROOT             Children
+//	ELEMENT          TagName, Attrs, Children
+//	STRING           Quote, Children (TEXT and INTERPOLATION segments)
+//	TEXT             Text
+//	INTERPOLATION    Value, ValueType
+//	FOR_DIRECTIVE    Binding, BindingType, Children (the loop body)
+//	IF_DIRECTIVE     Children (an IF_BRANCH for the @if, each @elseif and any @else)
+//	IF_BRANCH        Value, ValueType (the condition, empty for @else), Children
+//	SWITCH_DIRECTIVE Value, ValueType, Children (SWITCH_CASE nodes and at most one SWITCH_DEFAULT)
+//	SWITCH_CASE      Value (the unquoted case literal), Children
+//	SWITCH_DEFAULT   Children
+//	PROPS_DIRECTIVE  Children (a PROP for each declared prop)
+//	PROP             Value (the name), ValueType, Default (the literal as written, empty when there is none)
+//	IMPORT_DIRECTIVE Value (the unquoted path of the imported .wir file)
+//	COMPONENT        TagName (the capitalised name of an imported component), Attrs (the props passed to it), Children
+//	SLOT_DIRECTIVE   Value (the slot name, empty for the default slot), Children (the fallback content)
+//	FILL_DIRECTIVE   Value (the name of the slot it fills), Children
+type AstNode struct {
+	Kind        AstNodeKind       `json:"kind"`
+	IsRoot      bool              `json:"-"`
+	TagName     string            `json:"tagName,omitempty"`
+	Attrs       []AstAttr         `json:"attrs,omitempty"`
+	Quote       string            `json:"quote,omitempty"`
+	Text        string            `json:"text,omitempty"`
+	Value       string            `json:"value,omitempty"`
+	ValueType   string            `json:"valueType,omitempty"`
+	Binding     string            `json:"binding,omitempty"`
+	BindingType string            `json:"bindingType,omitempty"`
+	Default     string            `json:"default,omitempty"`
+	Children    []*AstNode        `json:"children,omitempty"`
+	Span        wirtokenizer.Span `json:"span"`
+}
+
+// AstAttr is an html attribute on an element. Parts holds the TEXT and
+// INTERPOLATION segments of the value in source order and is empty for
+// attributes written without a value.
+type AstAttr struct {
+	Key   string            `json:"key"`
+	Parts []*AstNode        `json:"parts,omitempty"`
+	Span  wirtokenizer.Span `json:"span"`
+}
+
+// Ast is a parsed .wir file. Props holds its @props declaration, which is
+// nil when the file leaves its props to be inferred, and Imports its @import
+// directives.
+type Ast struct {
+	Root    *AstNode   `json:"root"`
+	Props   *AstNode   `json:"props,omitempty"`
+	Imports []*AstNode `json:"imports,omitempty"`
+}
+
+// Json renders the tree as indented JSON. Field order follows the struct
+// definitions so the output is stable enough to diff.
+func (a *Ast) Json() (string, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	err := enc.Encode(a)
+	if err != nil {
+		return "", wherr.Consume(wherr.Here(), err, "")
+	}
+	return buf.String(), nil
+}
+
+func (n *AstNode) Iter(fn func(child *AstNode, index int) bool) {
+	for i, child := range n.Children {
+		shouldContinue := fn(child, i)
+		if !shouldContinue {
+			break
+		}
+	}
+}
+
+// Walk visits n and every node beneath it depth first, including the parts
+// of element attributes. Returning false from fn skips the node's subtree.
+func (n *AstNode) Walk(fn func(node *AstNode) bool) {
+	if !fn(n) {
+		return
+	}
+	for _, attr := range n.Attrs {
+		for _, part := range attr.Parts {
+			part.Walk(fn)
+		}
+	}
+	for _, child := range n.Children {
+		child.Walk(fn)
+	}
+}
+
+func (a AstAttr) IsBool() bool {
+	return len(a.Parts) == 0
+}
@@ -2,41 +2,904 @@ package wirparser
 
 import (
 	"fmt"
+	"strings"
+	"unicode"
 
 	"github.com/phillip-england/wir/internal/runelexer"
-	"github.com/phillip-england/wir/internal/wherr"
+	"github.com/phillip-england/wir/internal/wirdiag"
 	"github.com/phillip-england/wir/internal/wirtokenizer"
 )
 
-type AstNode struct {
-	IsRoot bool
-	Children []AstNode
-}
-
-type Ast struct {
-	Root *AstNode
-}
-
 type Parser struct {
 	lexer *runelexer.AbstractLexer[wirtokenizer.Token]
+	ast   *Ast
+	diags wirdiag.List
 }
 
+// ParserNew parses toks into an Ast. Parsing carries on past syntax errors so
+// every problem is found in one pass; they are returned together as a
+// wirdiag.List alongside the parser holding whatever could be parsed.
 func ParserNew(toks []wirtokenizer.Token) (*Parser, error) {
-	l := runelexer.AbstractLexerNew(toks)
-	err := recursiveParse(l)
+	root := &AstNode{
+		Kind:   AstNodeKindRoot,
+		IsRoot: true,
+	}
+	p := &Parser{
+		lexer: runelexer.AbstractLexerNew(toks),
+		ast: &Ast{
+			Root: root,
+		},
+	}
+	p.recursiveParse(root)
+	if len(toks) > 0 {
+		root.Span = wirtokenizer.Span{
+			Start: toks[0].Span().Start,
+			End:   toks[len(toks)-1].Span().End,
+		}
+	}
+	return p, p.diags.Err()
+}
+
+// ParserNewFromTokenizer parses the tokens of tk, rendering any diagnostics
+// against the source tk was created from.
+func ParserNewFromTokenizer(tk *wirtokenizer.Tokenizer) (*Parser, error) {
+	p, err := ParserNew(tk.Lexer.Tokens())
 	if err != nil {
-		return &Parser{}, wherr.Consume(wherr.Here(), err, "")
+		return p, p.diags.Attach(tk.Path, tk.Source)
 	}
-	return &Parser{
-		lexer: l,
-	}, nil
+	return p, nil
+}
+
+func (p *Parser) Ast() *Ast {
+	return p.ast
+}
+
+func (p *Parser) recursiveParse(parent *AstNode) {
+	l := p.lexer
+	for {
+		tk := l.Item()
+		pos := l.Pos()
+		var node *AstNode
+		var diag *wirdiag.Diagnostic
+		switch tk.Type() {
+		default:
+			{
+				diag = diagAt(tk, "unexpected %s", describe(tk))
+			}
+		case wirtokenizer.TokenTypeEndOfFile:
+			{
+				return
+			}
+		case wirtokenizer.TokenTypeHTMLCurlyBraceClose:
+			{
+				if !parent.IsRoot {
+					return
+				}
+				diag = diagAt(tk, "unexpected '}' with no matching '{'")
+			}
+		case wirtokenizer.TokenTypeHTMLTagName:
+			{
+				node, diag = p.parseElement()
+			}
+		case wirtokenizer.TokenTypeStringStart:
+			{
+				node, diag = p.parseString()
+			}
+		case wirtokenizer.TokenTypeAtDirectiveStart:
+			{
+				node, diag = p.parseDirective()
+			}
+		}
+		if diag == nil && (node.Kind == AstNodeKindPropsDirective || node.Kind == AstNodeKindImportDirective) {
+			diag = p.declare(parent, node)
+			if diag == nil {
+				continue
+			}
+		}
+		if diag != nil {
+			p.diags = append(p.diags, diag)
+			p.resync(pos)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+}
+
+// resync skips the rest of a construct which failed to parse after starting
+// at token pos. It stops at the next tag name, string or directive outside
+// of any braces the construct opened, or at the '}' closing the enclosing
+// block, so parsing can carry on and report later errors.
+func (p *Parser) resync(pos int) {
+	l := p.lexer
+	if l.Pos() == pos {
+		l.Next()
+	}
+	depth := 0
+	for {
+		switch l.Item().Type() {
+		case wirtokenizer.TokenTypeEndOfFile:
+			{
+				return
+			}
+		case wirtokenizer.TokenTypeHTMLCurlyBraceOpen:
+			{
+				depth++
+			}
+		case wirtokenizer.TokenTypeHTMLCurlyBraceClose:
+			{
+				if depth == 0 {
+					return
+				}
+				depth--
+			}
+		case wirtokenizer.TokenTypeHTMLTagName, wirtokenizer.TokenTypeStringStart, wirtokenizer.TokenTypeAtDirectiveStart:
+			{
+				if depth == 0 {
+					return
+				}
+			}
+		}
+		l.Next()
+	}
+}
+
+func diagAt(tk wirtokenizer.Token, format string, args ...any) *wirdiag.Diagnostic {
+	return wirdiag.DiagnosticNew(tk.Span().Start, format, args...)
+}
+
+// describe names tk the way a template author would recognise it.
+func describe(tk wirtokenizer.Token) string {
+	if tk.Type() == wirtokenizer.TokenTypeEndOfFile || tk.Type() == "" {
+		return "end of file"
+	}
+	return fmt.Sprintf("'%s'", tk.Text())
+}
+
+// expect returns the current token and moves past it if it is of type t,
+// otherwise it reports that what was expected is missing.
+func expect(l *runelexer.AbstractLexer[wirtokenizer.Token], t wirtokenizer.TokenType, what string) (wirtokenizer.Token, *wirdiag.Diagnostic) {
+	tk := l.Item()
+	if tk.Type() != t {
+		return tk, diagAt(tk, "expected %s but found %s", what, describe(tk))
+	}
+	l.Next()
+	return tk, nil
+}
+
+// spanFrom returns the span from the start of tk to the end of the last
+// token consumed by l.
+func spanFrom(l *runelexer.AbstractLexer[wirtokenizer.Token], tk wirtokenizer.Token) wirtokenizer.Span {
+	return wirtokenizer.Span{
+		Start: tk.Span().Start,
+		End:   l.Peek(-1).Span().End,
+	}
+}
+
+// textNode creates a TEXT node for text found within tk, skipping the
+// leading quote the tokenizer leaves on attribute values.
+func textNode(tk wirtokenizer.Token, text string, quote string) *AstNode {
+	start := tk.Span().Start.Advance(quote)
+	return &AstNode{
+		Kind: AstNodeKindText,
+		Text: text,
+		Span: wirtokenizer.Span{
+			Start: start,
+			End:   start.Advance(text),
+		},
+	}
+}
+
+func (p *Parser) parseBlock(node *AstNode) *wirdiag.Diagnostic {
+	l := p.lexer
+	open, diag := expect(l, wirtokenizer.TokenTypeHTMLCurlyBraceOpen, "'{'")
+	if diag != nil {
+		return diag
+	}
+	p.recursiveParse(node)
+	if l.Item().Type() != wirtokenizer.TokenTypeHTMLCurlyBraceClose {
+		return diagAt(open, "unclosed '{', expected a matching '}'")
+	}
+	l.Next()
+	return nil
+}
+
+func (p *Parser) parseElement() (*AstNode, *wirdiag.Diagnostic) {
+	l := p.lexer
+	tk, diag := expect(l, wirtokenizer.TokenTypeHTMLTagName, "a tag name")
+	if diag != nil {
+		return nil, diag
+	}
+	if strings.HasPrefix(tk.Text(), "@") {
+		return nil, diagAt(tk, "unknown directive %s", tk.Text())
+	}
+	if !isTagName(tk.Text()) {
+		return nil, diagAt(tk, "invalid tag name '%s'", tk.Text())
+	}
+	node := &AstNode{
+		Kind:    AstNodeKindElement,
+		TagName: tk.Text(),
+	}
+	if unicode.IsUpper([]rune(tk.Text())[0]) {
+		node.Kind = AstNodeKindComponent
+	}
+	if l.Item().Type() == wirtokenizer.TokenTypeHTMLTagInfoStart {
+		l.Next()
+		for l.Item().Type() != wirtokenizer.TokenTypeHTMLTagInfoEnd {
+			attr, diag := p.parseAttr()
+			if diag != nil {
+				return nil, diag
+			}
+			node.Attrs = append(node.Attrs, attr)
+		}
+		l.Next()
+	}
+	if l.Item().Type() == wirtokenizer.TokenTypeHTMLCurlyBraceOpen {
+		diag := p.parseBlock(node)
+		if diag != nil {
+			return nil, diag
+		}
+	}
+	node.Span = spanFrom(l, tk)
+	return node, nil
+}
+
+func (p *Parser) parseAttr() (AstAttr, *wirdiag.Diagnostic) {
+	l := p.lexer
+	tk, diag := expect(l, wirtokenizer.TokenTypeHTMLAttrKey, "an attribute name")
+	if diag != nil {
+		return AstAttr{}, diag
+	}
+	if tk.Text() == "" {
+		return AstAttr{}, diagAt(tk, "missing attribute name before '='")
+	}
+	attr := AstAttr{
+		Key: tk.Text(),
+	}
+	if l.Item().Type() != wirtokenizer.TokenTypeHTMLAttrEqualSign {
+		attr.Span = tk.Span()
+		return attr, nil
+	}
+	l.Next()
+	keyTk := tk
+	tk = l.Item()
+	switch tk.Type() {
+	default:
+		{
+			return attr, diagAt(keyTk, "missing a quoted value for attribute %s", attr.Key)
+		}
+	case wirtokenizer.TokenTypeHTMLAttrValue:
+		{
+			text := unquote(tk.Text())
+			if text != "" {
+				attr.Parts = append(attr.Parts, textNode(tk, text, tk.Text()[0:1]))
+			}
+			l.Next()
+		}
+	case wirtokenizer.TokenTypeHTMLAttrValuePartial:
+		{
+			parts, diag := p.parseAttrPartials()
+			if diag != nil {
+				return attr, diag
+			}
+			attr.Parts = parts
+		}
+	}
+	attr.Span = spanFrom(l, keyTk)
+	return attr, nil
 }
 
-func recursiveParse(l *runelexer.AbstractLexer[wirtokenizer.Token]) (error) {
-	l.Iter(func(item wirtokenizer.Token, pos int) bool {
-		fmt.Println(item.Str())
-		return true
-	})	
+// parseAttrPartials reassembles an attribute value which the tokenizer broke
+// apart around its interpolations. The first partial carries the opening
+// quote and the last carries the closing quote.
+func (p *Parser) parseAttrPartials() ([]*AstNode, *wirdiag.Diagnostic) {
+	l := p.lexer
+	var parts []*AstNode
+	quote := ""
+	for {
+		tk := l.Item()
+		switch tk.Type() {
+		default:
+			{
+				return parts, nil
+			}
+		case wirtokenizer.TokenTypeHTMLAttrValuePartial:
+			{
+				text := tk.Text()
+				skipped := ""
+				if quote == "" && text != "" {
+					quote = text[0:1]
+					skipped = quote
+					text = text[1:]
+				}
+				next := l.Peek(1).Type()
+				if next != wirtokenizer.TokenTypeHTMLAttrValuePartial && next != wirtokenizer.TokenTypeDollarSignInterpolationOpen {
+					text = strings.TrimSuffix(text, quote)
+				}
+				if text != "" {
+					parts = append(parts, textNode(tk, text, skipped))
+				}
+				l.Next()
+			}
+		case wirtokenizer.TokenTypeDollarSignInterpolationOpen:
+			{
+				node, diag := p.parseInterpolation()
+				if diag != nil {
+					return nil, diag
+				}
+				parts = append(parts, node)
+			}
+		}
+	}
+}
+
+func (p *Parser) parseInterpolation() (*AstNode, *wirdiag.Diagnostic) {
+	l := p.lexer
+	open, diag := expect(l, wirtokenizer.TokenTypeDollarSignInterpolationOpen, "'${'")
+	if diag != nil {
+		return nil, diag
+	}
+	tk, diag := expect(l, wirtokenizer.TokenTypeDollarSignInterpolationValue, "a value")
+	if diag != nil {
+		return nil, diag
+	}
+	if tk.Text() == "" {
+		return nil, diagAt(open, "empty interpolation, expected a value such as ${name: string}")
+	}
+	node := &AstNode{
+		Kind:  AstNodeKindInterpolation,
+		Value: tk.Text(),
+	}
+	if l.Item().Type() == wirtokenizer.TokenTypeDollarSignInterpolationSemiColon {
+		l.Next()
+	}
+	if l.Item().Type() == wirtokenizer.TokenTypeDollarSignInterpolationType {
+		node.ValueType = l.Item().Text()
+		l.Next()
+	}
+	if l.Item().Type() != wirtokenizer.TokenTypeDollarSignInterpolationClose {
+		return nil, diagAt(l.Item(), "unexpected %s in interpolation, expected '}'", describe(l.Item()))
+	}
+	l.Next()
+	node.Span = spanFrom(l, open)
+	return node, nil
+}
+
+func (p *Parser) parseString() (*AstNode, *wirdiag.Diagnostic) {
+	l := p.lexer
+	tk, diag := expect(l, wirtokenizer.TokenTypeStringStart, "a string")
+	if diag != nil {
+		return nil, diag
+	}
+	node := &AstNode{
+		Kind:  AstNodeKindString,
+		Quote: tk.Text(),
+	}
+	start := tk
+	for {
+		tk := l.Item()
+		switch tk.Type() {
+		default:
+			{
+				return nil, diagAt(start, "unterminated string, expected a closing %s", start.Text())
+			}
+		case wirtokenizer.TokenTypeStringEnd:
+			{
+				l.Next()
+				node.Span = spanFrom(l, start)
+				return node, nil
+			}
+		case wirtokenizer.TokenTypeStringContent:
+			{
+				if tk.Text() != "" {
+					node.Children = append(node.Children, textNode(tk, tk.Text(), ""))
+				}
+				l.Next()
+			}
+		case wirtokenizer.TokenTypeDollarSignInterpolationOpen:
+			{
+				child, diag := p.parseInterpolation()
+				if diag != nil {
+					return nil, diag
+				}
+				node.Children = append(node.Children, child)
+			}
+		}
+	}
+}
+
+func (p *Parser) parseDirective() (*AstNode, *wirdiag.Diagnostic) {
+	l := p.lexer
+	start, diag := expect(l, wirtokenizer.TokenTypeAtDirectiveStart, "'@'")
+	if diag != nil {
+		return nil, diag
+	}
+	name := l.Item()
+	var node *AstNode
+	switch name.Type() {
+	default:
+		{
+			return nil, diagAt(name, "expected a directive name but found %s", describe(name))
+		}
+	case wirtokenizer.TokenTypeAtDirectiveElseIf, wirtokenizer.TokenTypeAtDirectiveElse:
+		{
+			return nil, diagAt(start, "@%s without a matching @if", name.Text())
+		}
+	case wirtokenizer.TokenTypeAtDirectiveIf:
+		{
+			node, diag = p.parseIfDirective(start)
+		}
+	case wirtokenizer.TokenTypeAtDirectiveCase, wirtokenizer.TokenTypeAtDirectiveDefault:
+		{
+			return nil, diagAt(start, "@%s outside of a @switch", name.Text())
+		}
+	case wirtokenizer.TokenTypeAtDirectiveSwitch:
+		{
+			l.Next()
+			node, diag = p.parseSwitchDirective()
+		}
+	case wirtokenizer.TokenTypeAtDirectiveProps:
+		{
+			l.Next()
+			node, diag = p.parsePropsDirective()
+		}
+	case wirtokenizer.TokenTypeAtDirectiveImport:
+		{
+			l.Next()
+			node, diag = p.parseImportDirective()
+		}
+	case wirtokenizer.TokenTypeAtDirectiveName:
+		{
+			l.Next()
+			switch name.Text() {
+			default:
+				{
+					return nil, diagAt(name, "unknown directive @%s", name.Text())
+				}
+			case "for":
+				{
+					node, diag = p.parseForDirective()
+				}
+			case "slot":
+				{
+					node, diag = p.parseSlotDirective()
+				}
+			case "fill":
+				{
+					node, diag = p.parseFillDirective()
+				}
+			}
+		}
+	}
+	if diag != nil {
+		return nil, diag
+	}
+	node.Span = spanFrom(l, start)
+	return node, nil
+}
+
+// declare records node as the @props or one of the @import directives of the
+// file, which must come before any markup. @props may only be declared once
+// and each file only imported once.
+func (p *Parser) declare(parent *AstNode, node *AstNode) *wirdiag.Diagnostic {
+	name := "@props"
+	if node.Kind == AstNodeKindImportDirective {
+		name = "@import"
+	}
+	if !parent.IsRoot || len(parent.Children) > 0 {
+		return wirdiag.DiagnosticNew(node.Span.Start, "%s must come first in the file, before any markup", name)
+	}
+	if node.Kind == AstNodeKindImportDirective {
+		for _, imp := range p.ast.Imports {
+			if imp.Value == node.Value {
+				return wirdiag.DiagnosticNew(node.Span.Start, "duplicate @import('%s'), already imported at %s", node.Value, imp.Span.Start.Str())
+			}
+		}
+		p.ast.Imports = append(p.ast.Imports, node)
+		return nil
+	}
+	if p.ast.Props != nil {
+		return wirdiag.DiagnosticNew(node.Span.Start, "@props is already declared at %s", p.ast.Props.Span.Start.Str())
+	}
+	p.ast.Props = node
 	return nil
 }
 
+// parseImportDirective parses @import('path') naming another .wir file whose
+// component this one uses.
+func (p *Parser) parseImportDirective() (*AstNode, *wirdiag.Diagnostic) {
+	lit, diag := p.parseQuotedParam("import", "import path", "name.wir")
+	if diag != nil {
+		return nil, diag
+	}
+	return &AstNode{
+		Kind:  AstNodeKindImportDirective,
+		Value: lit,
+	}, nil
+}
+
+// parseQuotedParam parses the ('literal') following @directive, where what
+// names the literal and example is shown when it is missing.
+func (p *Parser) parseQuotedParam(directive string, what string, example string) (string, *wirdiag.Diagnostic) {
+	l := p.lexer
+	open, diag := expect(l, wirtokenizer.TokenTypeAtDirectiveParenthesisOpen, "'('")
+	if diag != nil {
+		return "", diag
+	}
+	value, diag := expect(l, wirtokenizer.TokenTypeAtDirectiveParamValue, "a "+what)
+	if diag != nil {
+		return "", diag
+	}
+	lit := unquote(value.Text())
+	if lit == "" {
+		return "", diagAt(open, "missing %s, expected @%s('%s')", what, directive, example)
+	}
+	if lit == value.Text() {
+		return "", diagAt(value, "%s %s must be quoted, such as @%s('%s')", what, lit, directive, lit)
+	}
+	_, diag = expect(l, wirtokenizer.TokenTypeAtDirectiveParenthesisClose, "')'")
+	if diag != nil {
+		return "", diag
+	}
+	return lit, nil
+}
+
+// parseSlotDirective parses @slot or @slot('name'), optionally followed by the
+// fallback content rendered when the caller doesn't fill the slot.
+func (p *Parser) parseSlotDirective() (*AstNode, *wirdiag.Diagnostic) {
+	l := p.lexer
+	node := &AstNode{
+		Kind: AstNodeKindSlotDirective,
+	}
+	if l.Item().Type() == wirtokenizer.TokenTypeAtDirectiveParenthesisOpen {
+		name, diag := p.parseQuotedParam("slot", "slot name", "name")
+		if diag != nil {
+			return nil, diag
+		}
+		node.Value = name
+	}
+	if l.Item().Type() != wirtokenizer.TokenTypeHTMLCurlyBraceOpen {
+		return node, nil
+	}
+	diag := p.parseBlock(node)
+	if diag != nil {
+		return nil, diag
+	}
+	return node, nil
+}
+
+// parseFillDirective parses @fill('name') { } passing content to the named
+// slot of the component it is written in.
+func (p *Parser) parseFillDirective() (*AstNode, *wirdiag.Diagnostic) {
+	l := p.lexer
+	name, diag := p.parseQuotedParam("fill", "slot name", "name")
+	if diag != nil {
+		return nil, diag
+	}
+	if l.Item().Type() != wirtokenizer.TokenTypeHTMLCurlyBraceOpen {
+		return nil, diagAt(l.Item(), "expected '{' to open the body of @fill but found %s", describe(l.Item()))
+	}
+	node := &AstNode{
+		Kind:  AstNodeKindFillDirective,
+		Value: name,
+	}
+	diag = p.parseBlock(node)
+	if diag != nil {
+		return nil, diag
+	}
+	return node, nil
+}
+
+// parsePropsDirective parses @props(name: Type = default, ...) into a PROP
+// node for each declared prop.
+func (p *Parser) parsePropsDirective() (*AstNode, *wirdiag.Diagnostic) {
+	l := p.lexer
+	node := &AstNode{
+		Kind: AstNodeKindPropsDirective,
+	}
+	_, diag := expect(l, wirtokenizer.TokenTypeAtDirectiveParenthesisOpen, "'('")
+	if diag != nil {
+		return nil, diag
+	}
+	seen := map[string]*AstNode{}
+	for l.Item().Type() != wirtokenizer.TokenTypeAtDirectiveParenthesisClose {
+		if len(node.Children) > 0 {
+			_, diag := expect(l, wirtokenizer.TokenTypeAtDirectiveComma, "',' between props")
+			if diag != nil {
+				return nil, diag
+			}
+			if l.Item().Type() == wirtokenizer.TokenTypeAtDirectiveParenthesisClose {
+				break
+			}
+		}
+		prop, diag := p.parseProp()
+		if diag != nil {
+			return nil, diag
+		}
+		first, exists := seen[prop.Value]
+		if exists {
+			return nil, wirdiag.DiagnosticNew(prop.Span.Start, "duplicate prop %s, already declared at %s", prop.Value, first.Span.Start.Str())
+		}
+		seen[prop.Value] = prop
+		node.Children = append(node.Children, prop)
+	}
+	l.Next()
+	return node, nil
+}
+
+// parseProp parses a single name: Type of @props and the default following
+// it, if any.
+func (p *Parser) parseProp() (*AstNode, *wirdiag.Diagnostic) {
+	l := p.lexer
+	name, diag := expect(l, wirtokenizer.TokenTypeAtDirectiveParamValue, "a prop name")
+	if diag != nil {
+		return nil, diag
+	}
+	if name.Text() == "" {
+		return nil, diagAt(name, "missing prop name, expected name: Type")
+	}
+	node := &AstNode{
+		Kind:  AstNodeKindProp,
+		Value: name.Text(),
+	}
+	if l.Item().Type() == wirtokenizer.TokenTypeAtDirectiveSemiColon {
+		l.Next()
+	}
+	if l.Item().Type() == wirtokenizer.TokenTypeAtDirectiveParamType {
+		node.ValueType = l.Item().Text()
+		l.Next()
+	}
+	if node.ValueType == "" {
+		return nil, diagAt(name, "missing type for prop %s, expected %s: Type", node.Value, node.Value)
+	}
+	if l.Item().Type() == wirtokenizer.TokenTypeAtDirectiveEqualSign {
+		eq := l.Item()
+		l.Next()
+		def, diag := expect(l, wirtokenizer.TokenTypeAtDirectiveParamDefault, "a default value")
+		if diag != nil {
+			return nil, diag
+		}
+		if def.Text() == "" {
+			return nil, diagAt(eq, "missing default value for %s after '='", node.Value)
+		}
+		node.Default = def.Text()
+	}
+	node.Span = spanFrom(l, name)
+	return node, nil
+}
+
+// parseIfDirective parses an @if along with the @elseif and @else directives
+// directly following it, each becoming a branch of one IF_DIRECTIVE node.
+// start is the '@' of the @if, whose name token l is on.
+func (p *Parser) parseIfDirective(start wirtokenizer.Token) (*AstNode, *wirdiag.Diagnostic) {
+	l := p.lexer
+	node := &AstNode{
+		Kind: AstNodeKindIfDirective,
+	}
+	for {
+		name := l.Item()
+		l.Next()
+		branch := &AstNode{
+			Kind: AstNodeKindIfBranch,
+		}
+		if name.Type() != wirtokenizer.TokenTypeAtDirectiveElse {
+			diag := p.parseValueParam(branch, "condition", "@"+name.Text()+"(name: bool)")
+			if diag != nil {
+				return nil, diag
+			}
+		}
+		if l.Item().Type() != wirtokenizer.TokenTypeHTMLCurlyBraceOpen {
+			return nil, diagAt(l.Item(), "expected '{' to open the body of @%s but found %s", name.Text(), describe(l.Item()))
+		}
+		diag := p.parseBlock(branch)
+		if diag != nil {
+			return nil, diag
+		}
+		branch.Span = spanFrom(l, start)
+		node.Children = append(node.Children, branch)
+		if name.Type() == wirtokenizer.TokenTypeAtDirectiveElse {
+			return node, nil
+		}
+		next := l.Peek(1).Type()
+		if l.Item().Type() != wirtokenizer.TokenTypeAtDirectiveStart || (next != wirtokenizer.TokenTypeAtDirectiveElseIf && next != wirtokenizer.TokenTypeAtDirectiveElse) {
+			return node, nil
+		}
+		start = l.Item()
+		l.Next()
+	}
+}
+
+// parseValueParam parses the (name: Type) of an @if, @elseif or @switch into
+// the Value and ValueType of node. The type may be left out. what and usage
+// describe the parameter when it is missing.
+func (p *Parser) parseValueParam(node *AstNode, what string, usage string) *wirdiag.Diagnostic {
+	l := p.lexer
+	open, diag := expect(l, wirtokenizer.TokenTypeAtDirectiveParenthesisOpen, "'('")
+	if diag != nil {
+		return diag
+	}
+	value, diag := expect(l, wirtokenizer.TokenTypeAtDirectiveParamValue, "a "+what)
+	if diag != nil {
+		return diag
+	}
+	if value.Text() == "" {
+		return diagAt(open, "missing %s, expected %s", what, usage)
+	}
+	node.Value = value.Text()
+	if l.Item().Type() == wirtokenizer.TokenTypeAtDirectiveSemiColon {
+		l.Next()
+	}
+	if l.Item().Type() == wirtokenizer.TokenTypeAtDirectiveParamType {
+		node.ValueType = l.Item().Text()
+		l.Next()
+	}
+	_, diag = expect(l, wirtokenizer.TokenTypeAtDirectiveParenthesisClose, "')'")
+	return diag
+}
+
+// parseSwitchDirective parses a @switch and the @case and @default arms in
+// its body. Problems with its arms are reported without abandoning the switch
+// so the rest of the file is still checked.
+func (p *Parser) parseSwitchDirective() (*AstNode, *wirdiag.Diagnostic) {
+	l := p.lexer
+	node := &AstNode{
+		Kind: AstNodeKindSwitchDirective,
+	}
+	diag := p.parseValueParam(node, "value", "@switch(name: Type)")
+	if diag != nil {
+		return nil, diag
+	}
+	open, diag := expect(l, wirtokenizer.TokenTypeHTMLCurlyBraceOpen, "'{' to open the body of @switch")
+	if diag != nil {
+		return nil, diag
+	}
+	seen := map[string]*AstNode{}
+	var fallback *AstNode
+	hasCase := false
+	for {
+		tk := l.Item()
+		switch tk.Type() {
+		default:
+			{
+				p.diags = append(p.diags, diagAt(tk, "expected @case or @default inside @switch but found %s", describe(tk)))
+				p.resync(l.Pos())
+			}
+		case wirtokenizer.TokenTypeEndOfFile:
+			{
+				return nil, diagAt(open, "unclosed '{', expected a matching '}'")
+			}
+		case wirtokenizer.TokenTypeHTMLCurlyBraceClose:
+			{
+				l.Next()
+				if !hasCase {
+					return nil, diagAt(open, "@switch has no @case")
+				}
+				return node, nil
+			}
+		case wirtokenizer.TokenTypeAtDirectiveStart:
+			{
+				pos := l.Pos()
+				hasCase = hasCase || l.Peek(1).Type() == wirtokenizer.TokenTypeAtDirectiveCase
+				arm, diag := p.parseSwitchArm()
+				if diag != nil {
+					p.diags = append(p.diags, diag)
+					p.resync(pos)
+					continue
+				}
+				if arm.Kind == AstNodeKindSwitchDefault {
+					if fallback != nil {
+						p.diags = append(p.diags, diagAt(tk, "@switch already has a @default at %s", fallback.Span.Start.Str()))
+					}
+					fallback = arm
+				} else {
+					first, exists := seen[arm.Value]
+					if exists {
+						p.diags = append(p.diags, diagAt(tk, "duplicate @case('%s'), already handled at %s", arm.Value, first.Span.Start.Str()))
+					} else {
+						seen[arm.Value] = arm
+					}
+				}
+				node.Children = append(node.Children, arm)
+			}
+		}
+	}
+}
+
+// parseSwitchArm parses a single @case('value') { } or @default { }.
+func (p *Parser) parseSwitchArm() (*AstNode, *wirdiag.Diagnostic) {
+	l := p.lexer
+	start := l.Item()
+	l.Next()
+	name := l.Item()
+	node := &AstNode{
+		Kind: AstNodeKindSwitchDefault,
+	}
+	switch name.Type() {
+	default:
+		{
+			return nil, diagAt(start, "expected @case or @default inside @switch but found @%s", name.Text())
+		}
+	case wirtokenizer.TokenTypeAtDirectiveDefault:
+		{
+			l.Next()
+		}
+	case wirtokenizer.TokenTypeAtDirectiveCase:
+		{
+			l.Next()
+			value, diag := p.parseQuotedParam("case", "case value", "value")
+			if diag != nil {
+				return nil, diag
+			}
+			node.Kind = AstNodeKindSwitchCase
+			node.Value = value
+		}
+	}
+	if l.Item().Type() != wirtokenizer.TokenTypeHTMLCurlyBraceOpen {
+		return nil, diagAt(l.Item(), "expected '{' to open the body of @%s but found %s", name.Text(), describe(l.Item()))
+	}
+	diag := p.parseBlock(node)
+	if diag != nil {
+		return nil, diag
+	}
+	node.Span = spanFrom(l, start)
+	return node, nil
+}
+
+func (p *Parser) parseForDirective() (*AstNode, *wirdiag.Diagnostic) {
+	l := p.lexer
+	open, diag := expect(l, wirtokenizer.TokenTypeAtDirectiveParenthesisOpen, "'('")
+	if diag != nil {
+		return nil, diag
+	}
+	binding, diag := expect(l, wirtokenizer.TokenTypeAtDirectiveParamValue, "a loop variable")
+	if diag != nil {
+		return nil, diag
+	}
+	if binding.Text() == "" {
+		return nil, diagAt(open, "missing loop variable, expected @for(name: Type)")
+	}
+	if l.Item().Type() == wirtokenizer.TokenTypeAtDirectiveSemiColon {
+		l.Next()
+	}
+	bindingType := l.Item()
+	if bindingType.Type() != wirtokenizer.TokenTypeAtDirectiveParamType || bindingType.Text() == "" {
+		return nil, diagAt(binding, "missing type for loop variable %s, expected @for(%s: Type)", binding.Text(), binding.Text())
+	}
+	l.Next()
+	_, diag = expect(l, wirtokenizer.TokenTypeAtDirectiveParenthesisClose, "')'")
+	if diag != nil {
+		return nil, diag
+	}
+	node := &AstNode{
+		Kind:        AstNodeKindForDirective,
+		Binding:     binding.Text(),
+		BindingType: bindingType.Text(),
+	}
+	if l.Item().Type() != wirtokenizer.TokenTypeHTMLCurlyBraceOpen {
+		return nil, diagAt(l.Item(), "expected '{' to open the body of @for but found %s", describe(l.Item()))
+	}
+	diag = p.parseBlock(node)
+	if diag != nil {
+		return nil, diag
+	}
+	return node, nil
+}
+
+func isTagName(s string) bool {
+	for i, r := range s {
+		isLetter := (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if i == 0 && !isLetter {
+			return false
+		}
+		if !isLetter && !(r >= '0' && r <= '9') && r != '-' && r != '_' && r != '.' && r != ':' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -47,7 +47,17 @@ const (
 	TokenTypeAtDirectiveParamValue       = "AT_DIRECTIVE_PARAM_VALUE"
 	TokenTypeAtDirectiveSemiColon        = "AT_DIRECTIVE_SEMICOLON"
 	TokenTypeAtDirectiveParamType        = "AT_DIRECTIVE_PARAM_TYPE"
-
+	TokenTypeAtDirectiveIf               = "AT_DIRECTIVE_IF"
+	TokenTypeAtDirectiveElseIf           = "AT_DIRECTIVE_ELSE_IF"
+	TokenTypeAtDirectiveElse             = "AT_DIRECTIVE_ELSE"
+	TokenTypeAtDirectiveSwitch           = "AT_DIRECTIVE_SWITCH"
+	TokenTypeAtDirectiveCase             = "AT_DIRECTIVE_CASE"
+	TokenTypeAtDirectiveDefault          = "AT_DIRECTIVE_DEFAULT"
+	TokenTypeAtDirectiveProps            = "AT_DIRECTIVE_PROPS"
+	TokenTypeAtDirectiveImport           = "AT_DIRECTIVE_IMPORT"
+	TokenTypeAtDirectiveComma            = "AT_DIRECTIVE_COMMA"
+	TokenTypeAtDirectiveEqualSign        = "AT_DIRECTIVE_EQUAL_SIGN"
+	TokenTypeAtDirectiveParamDefault     = "AT_DIRECTIVE_PARAM_DEFAULT"
 
 	TokenTypeEndOfFile = "END_OF_FILE"
 )
@@ -55,12 +65,35 @@ const (
 type Token struct {
 	t    TokenType
 	text string
+	span Span
+}
+
+// Span covers the source text a token was produced from. End is exclusive.
+type Span struct {
+	Start runelexer.Position `json:"start"`
+	End   runelexer.Position `json:"end"`
+}
+
+func (s Span) Str() string {
+	return fmt.Sprintf("%s-%s", s.Start.Str(), s.End.Str())
 }
 
 func (t Token) Str() string {
 	return fmt.Sprintf("%s:%s", t.t, t.text)
 }
 
+func (t Token) Type() TokenType {
+	return t.t
+}
+
+func (t Token) Text() string {
+	return t.text
+}
+
+func (t Token) Span() Span {
+	return t.span
+}
+
 func TokenManyFromStr(s string) ([]Token, error) {
 	var toks []Token
 	lines := strings.Split(s, "\n")
@@ -3,13 +3,17 @@ package wirtokenizer
 import (
 	"os"
 	"strings"
+	"unicode"
 
 	"github.com/phillip-england/wir/internal/runelexer"
 	"github.com/phillip-england/wir/internal/wherr"
+	"github.com/phillip-england/wir/internal/wirdiag"
 )
 
 type Tokenizer struct {
-	Lexer *runelexer.RuneLexer[Token]
+	Lexer  *runelexer.RuneLexer[Token]
+	Path   string
+	Source string
 }
 
 func TokenizerNewFromFile(path string) (*Tokenizer, error) {
@@ -18,22 +22,32 @@ func TokenizerNewFromFile(path string) (*Tokenizer, error) {
 		return &Tokenizer{}, wherr.Consume(wherr.Here(), err, "")
 	}
 	fStr := string(fBytes)
-	tk, err := TokenizerNewFromString(fStr)
+	tk, err := TokenizerNewFromSource(path, fStr)
 	if err != nil {
-		return &Tokenizer{}, wherr.Consume(wherr.Here(), err, "")
+		return &Tokenizer{}, err
 	}
 	return tk, nil
 }
 
 func TokenizerNewFromString(s string) (*Tokenizer, error) {
-	s = strings.TrimSpace(s)
-	l := runelexer.NewRuneLexer[Token](s)
-	err := tokenizeWir(l)
-	if err != nil {
-		return &Tokenizer{}, err
+	return TokenizerNewFromSource("", s)
+}
+
+// TokenizerNewFromSource tokenizes s, naming path as its origin in any
+// diagnostics. Syntax errors are returned together as a wirdiag.List.
+func TokenizerNewFromSource(path string, s string) (*Tokenizer, error) {
+	trimmed := strings.TrimLeftFunc(s, unicode.IsSpace)
+	origin := runelexer.PositionStart().Advance(s[:len(s)-len(trimmed)])
+	trimmed = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+	l := runelexer.NewRuneLexerAt[Token](trimmed, origin)
+	diags := tokenizeWir(l)
+	if len(diags) > 0 {
+		return &Tokenizer{}, diags.Attach(path, s)
 	}
 	return &Tokenizer{
-		Lexer: l,
+		Lexer:  l,
+		Path:   path,
+		Source: s,
 	}, nil
 }
 
@@ -55,23 +69,224 @@ const (
 	TokenStateInit = iota
 )
 
-func tokenizeWir(l *runelexer.RuneLexer[Token]) error {
-	err := phase1(l)
-	if err != nil {
-		return err
+func tokenizeWir(l *runelexer.RuneLexer[Token]) wirdiag.List {
+	diags := phase1(l)
+	diags = append(diags, phase2(l)...)
+	diags = append(diags, phase3(l)...)
+	return diags
+}
+
+// spanOf returns the span covering the runes of l from start up to but not
+// including end.
+func spanOf(l *runelexer.RuneLexer[Token], start int, end int) Span {
+	return Span{
+		Start: l.PositionAt(start),
+		End:   l.PositionAt(end),
 	}
-	err = phase2(l)
-	if err != nil {
-		return err
+}
+
+// storeFlushSpan flushes the store of l and returns its text along with the
+// span it was collected from. An empty store gives an empty span at pos.
+func storeFlushSpan(l *runelexer.RuneLexer[Token], pos int) (string, Span) {
+	if l.StoreLen() == 0 {
+		return "", spanOf(l, pos, pos)
 	}
-	err = phase3(l)
-	if err != nil {
-		return err
+	start := l.StoreStart()
+	s := l.StoreFlush()
+	return s, spanOf(l, start, start+len([]rune(s)))
+}
+
+// trimSpan trims whitespace from s, which begins at rune index start within
+// l, and returns the trimmed text with the span it covers.
+func trimSpan(l *runelexer.RuneLexer[Token], s string, start int) (string, Span) {
+	lead := len([]rune(s)) - len([]rune(strings.TrimLeftFunc(s, unicode.IsSpace)))
+	trimmed := strings.TrimSpace(s)
+	start += lead
+	return trimmed, spanOf(l, start, start+len([]rune(trimmed)))
+}
+
+// splitParams splits the text of a directive or interpolation on ':' into
+// value and type tokens. s begins at rune index start within l.
+func splitParams(l *runelexer.RuneLexer[Token], s string, start int, valueType TokenType, colonType TokenType, typeType TokenType) []Token {
+	var toks []Token
+	parts := strings.Split(s, ":")
+	for i, v := range parts {
+		text, span := trimSpan(l, v, start)
+		start += len([]rune(v))
+		if i%2 == 0 {
+			toks = append(toks, Token{
+				t:    valueType,
+				text: text,
+				span: span,
+			})
+			colonSpan := spanOf(l, start, start)
+			if i < len(parts)-1 {
+				colonSpan = spanOf(l, start, start+1)
+			}
+			toks = append(toks, Token{
+				t:    colonType,
+				text: ":",
+				span: colonSpan,
+			})
+		} else {
+			toks = append(toks, Token{
+				t:    typeType,
+				text: text,
+				span: span,
+			})
+		}
+		start++
 	}
-	return nil
+	return toks
+}
+
+// splitProps splits the parameters of @props on ',' into the name, ':' and
+// type of each prop, followed by '=' and its default when one is given. A
+// trailing comma is allowed. s begins at rune index start within l.
+func splitProps(l *runelexer.RuneLexer[Token], s string, start int) []Token {
+	var toks []Token
+	params := splitUnquoted(s, ',')
+	for i, param := range params {
+		if i > 0 {
+			toks = append(toks, Token{
+				t:    TokenTypeAtDirectiveComma,
+				text: ",",
+				span: spanOf(l, start-1, start),
+			})
+		}
+		if i == len(params)-1 && strings.TrimSpace(param) == "" {
+			break
+		}
+		parts := splitUnquoted(param, '=')
+		toks = append(toks, splitParams(l, parts[0], start,
+			TokenTypeAtDirectiveParamValue,
+			TokenTypeAtDirectiveSemiColon,
+			TokenTypeAtDirectiveParamType,
+		)...)
+		if len(parts) > 1 {
+			at := start + len([]rune(parts[0]))
+			toks = append(toks, Token{
+				t:    TokenTypeAtDirectiveEqualSign,
+				text: "=",
+				span: spanOf(l, at, at+1),
+			})
+			text, span := trimSpan(l, strings.Join(parts[1:], "="), at+1)
+			toks = append(toks, Token{
+				t:    TokenTypeAtDirectiveParamDefault,
+				text: text,
+				span: span,
+			})
+		}
+		start += len([]rune(param)) + 1
+	}
+	return toks
+}
+
+// splitUnquoted splits s on each sep that is not inside a quoted string.
+func splitUnquoted(s string, sep rune) []string {
+	var parts []string
+	quote := rune(0)
+	escaped := false
+	last := 0
+	for i, r := range s {
+		switch {
+		case escaped:
+			escaped = false
+		case quote != 0 && r == '\\':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == sep:
+			parts = append(parts, s[last:i])
+			last = i + 1
+		}
+	}
+	return append(parts, s[last:])
 }
 
-func phase3(l *runelexer.RuneLexer[Token]) error {
+// directiveParams says whether a directive takes parameters in parentheses.
+type directiveParams int
+
+const (
+	paramsNone directiveParams = iota
+	paramsRequired
+	paramsOptional
+)
+
+// directives maps the name of each directive to the parameters it takes.
+var directives = map[string]directiveParams{
+	"for":     paramsRequired,
+	"if":      paramsRequired,
+	"elseif":  paramsRequired,
+	"else":    paramsNone,
+	"switch":  paramsRequired,
+	"case":    paramsRequired,
+	"default": paramsNone,
+	"props":   paramsRequired,
+	"import":  paramsRequired,
+	"slot":    paramsOptional,
+	"fill":    paramsRequired,
+}
+
+// directiveAt returns the letters following the '@' l is on.
+func directiveAt(l *runelexer.RuneLexer[Token]) string {
+	runes := l.Runes()
+	end := l.Pos() + 1
+	for end < len(runes) && unicode.IsLetter(runes[end]) {
+		end++
+	}
+	return string(runes[l.Pos()+1 : end])
+}
+
+// directiveNameType gives the conditional directives and those heading a file
+// their own token types so the parser can tell the links of an @if chain, the
+// arms of a @switch and the declarations of a file apart.
+func directiveNameType(name string) TokenType {
+	switch name {
+	default:
+		{
+			return TokenTypeAtDirectiveName
+		}
+	case "if":
+		{
+			return TokenTypeAtDirectiveIf
+		}
+	case "elseif":
+		{
+			return TokenTypeAtDirectiveElseIf
+		}
+	case "else":
+		{
+			return TokenTypeAtDirectiveElse
+		}
+	case "switch":
+		{
+			return TokenTypeAtDirectiveSwitch
+		}
+	case "case":
+		{
+			return TokenTypeAtDirectiveCase
+		}
+	case "default":
+		{
+			return TokenTypeAtDirectiveDefault
+		}
+	case "props":
+		{
+			return TokenTypeAtDirectiveProps
+		}
+	case "import":
+		{
+			return TokenTypeAtDirectiveImport
+		}
+	}
+}
+
+func phase3(l *runelexer.RuneLexer[Token]) wirdiag.List {
 	var toks []Token
 	l.TokenIter(func(tk Token, index int) bool {
 		switch tk.t {
@@ -84,6 +299,7 @@ func phase3(l *runelexer.RuneLexer[Token]) error {
 				toks = append(toks, Token{
 					t:    TokenTypeHTMLCurlyBraceOpen,
 					text: tk.text,
+					span: tk.span,
 				})
 			}
 		case TokenTypeCurlyBraceClose:
@@ -91,6 +307,7 @@ func phase3(l *runelexer.RuneLexer[Token]) error {
 				toks = append(toks, Token{
 					t:    TokenTypeHTMLCurlyBraceClose,
 					text: tk.text,
+					span: tk.span,
 				})
 			}
 		case TokenTypeRawText:
@@ -98,40 +315,31 @@ func phase3(l *runelexer.RuneLexer[Token]) error {
 				toks = append(toks, Token{
 					t:    TokenTypeHTMLTagName,
 					text: tk.text,
+					span: tk.span,
 				})
 			}
 		case TokenTypeDollarSignInterpolation:
 			{
+				l2 := runelexer.NewRuneLexerAt[Token](tk.text, tk.span.Start)
 				toks = append(toks, Token{
 					t:    TokenTypeDollarSignInterpolationOpen,
 					text: "${",
+					span: spanOf(l2, 0, 2),
 				})
 				s := tk.text
 				s = strings.Replace(s, "${", "", 1)
 				s = s[0 : len(s)-1]
-				innerParts := strings.Split(s, ":")
-				for i, v := range innerParts {
-					if i%2 == 0 {
-						toks = append(toks, Token{
-							t:    TokenTypeDollarSignInterpolationValue,
-							text: strings.TrimSpace(v),
-						})
-						toks = append(toks, Token{
-							t:    TokenTypeDollarSignInterpolationSemiColon,
-							text: ":",
-						})
-					} else {
-						toks = append(toks, Token{
-							t:    TokenTypeDollarSignInterpolationType,
-							text: strings.TrimSpace(v),
-						})
-					}
-				}
+				toks = append(toks, splitParams(l2, s, 2,
+					TokenTypeDollarSignInterpolationValue,
+					TokenTypeDollarSignInterpolationSemiColon,
+					TokenTypeDollarSignInterpolationType,
+				)...)
+				toks = append(toks, Token{
+					t:    TokenTypeDollarSignInterpolationClose,
+					text: "}",
+					span: spanOf(l2, l2.Len()-1, l2.Len()),
+				})
 			}
-			toks = append(toks, Token{
-				t:    TokenTypeDollarSignInterpolationClose,
-				text: "}",
-			})
 		}
 		return true
 	})
@@ -139,8 +347,9 @@ func phase3(l *runelexer.RuneLexer[Token]) error {
 	return nil
 }
 
-func phase2(l *runelexer.RuneLexer[Token]) error {
+func phase2(l *runelexer.RuneLexer[Token]) wirdiag.List {
 	var toks []Token
+	var diags wirdiag.List
 	l.TokenIter(func(tk Token, index int) bool {
 		switch tk.t {
 		default:
@@ -149,7 +358,7 @@ func phase2(l *runelexer.RuneLexer[Token]) error {
 			}
 		case TokenTypeTagInfo:
 			{
-				l2 := runelexer.NewRuneLexer[Token](tk.text)
+				l2 := runelexer.NewRuneLexerAt[Token](tk.text, tk.span.Start)
 				l2.Iter(func(ch string, pos int) bool {
 					switch ch {
 					default:
@@ -158,14 +367,20 @@ func phase2(l *runelexer.RuneLexer[Token]) error {
 						}
 					case "=":
 						{
-							attrKey := strings.TrimSpace(l2.StoreFlush())
+							attrKey, span := "", spanOf(l2, pos, pos)
+							if l2.StoreLen() > 0 {
+								start := l2.StoreStart()
+								attrKey, span = trimSpan(l2, l2.StoreFlush(), start)
+							}
 							toks = append(toks, Token{
 								t:    TokenTypeHTMLAttrKey,
 								text: attrKey,
+								span: span,
 							})
 							toks = append(toks, Token{
 								t:    TokenTypeHTMLAttrEqualSign,
 								text: "=",
+								span: spanOf(l2, pos, pos+1),
 							})
 						}
 					case "<":
@@ -173,20 +388,26 @@ func phase2(l *runelexer.RuneLexer[Token]) error {
 							toks = append(toks, Token{
 								t:    TokenTypeHTMLTagInfoStart,
 								text: "<",
+								span: spanOf(l2, pos, pos+1),
 							})
 						}
 					case ">":
 						{
-							attrKey := strings.TrimSpace(l2.StoreFlush())
-							if attrKey != "" {
-								toks = append(toks, Token{
-									t:    TokenTypeHTMLAttrKey,
-									text: attrKey,
-								})
+							if l2.StoreLen() > 0 {
+								start := l2.StoreStart()
+								attrKey, span := trimSpan(l2, l2.StoreFlush(), start)
+								if attrKey != "" {
+									toks = append(toks, Token{
+										t:    TokenTypeHTMLAttrKey,
+										text: attrKey,
+										span: span,
+									})
+								}
 							}
 							toks = append(toks, Token{
 								t:    TokenTypeHTMLTagInfoEnd,
 								text: ">",
+								span: spanOf(l2, pos, pos+1),
 							})
 						}
 					case "'":
@@ -197,7 +418,7 @@ func phase2(l *runelexer.RuneLexer[Token]) error {
 								if ch2 == "'" && l2.Peek(-1) != "\\" {
 									htmlAttr := l2.PullFromMark()
 									brokeAttr := false
-									l3 := runelexer.NewRuneLexer[Token](htmlAttr)
+									l3 := runelexer.NewRuneLexerAt[Token](htmlAttr, l2.PositionAt(l2.MarkedPos()))
 									l3.Iter(func(ch3 string, pos int) bool {
 										switch ch3 {
 										default:
@@ -210,30 +431,38 @@ func phase2(l *runelexer.RuneLexer[Token]) error {
 												return true
 											}
 											brokeAttr = true
-											attrBit := l3.StoreFlush()
+											attrBit, span := storeFlushSpan(l3, pos)
 											toks = append(toks, Token{
 												t:    TokenTypeHTMLAttrValuePartial,
 												text: attrBit,
+												span: span,
 											})
 											l3.Mark()
-											l3.NextUntil("}")
+											if !l3.NextUntil("}") {
+												diags = append(diags, wirdiag.DiagnosticNew(l3.PositionAt(pos), "unterminated interpolation, expected '}'"))
+												return false
+											}
 											dollarSignInterpolation := l3.PullFromMark()
 											toks = append(toks, Token{
 												t:    TokenTypeDollarSignInterpolation,
 												text: dollarSignInterpolation,
+												span: spanOf(l3, l3.MarkedPos(), l3.Pos()+1),
 											})
 										}
 										return true
 									})
 									if brokeAttr {
+										attrBit, span := storeFlushSpan(l3, l3.Len())
 										toks = append(toks, Token{
 											t:    TokenTypeHTMLAttrValuePartial,
-											text: l3.StoreFlush(),
+											text: attrBit,
+											span: span,
 										})
 									} else {
 										toks = append(toks, Token{
 											t:    TokenTypeHTMLAttrValue,
 											text: l3.PullFromMark(),
+											span: spanOf(l3, 0, l3.Len()),
 										})
 									}
 									return false
@@ -250,6 +479,7 @@ func phase2(l *runelexer.RuneLexer[Token]) error {
 									toks = append(toks, Token{
 										t:    TokenTypeHTMLAttrValue,
 										text: l2.PullFromMark(),
+										span: spanOf(l2, l2.MarkedPos(), pos+1),
 									})
 									return false
 								}
@@ -262,7 +492,7 @@ func phase2(l *runelexer.RuneLexer[Token]) error {
 			}
 		case TokenTypeString:
 			{
-				l2 := runelexer.NewRuneLexer[Token](tk.text)
+				l2 := runelexer.NewRuneLexerAt[Token](tk.text, tk.span.Start)
 				l2.Iter(func(ch string, pos int) bool {
 					switch ch {
 					default:
@@ -275,15 +505,21 @@ func phase2(l *runelexer.RuneLexer[Token]) error {
 								l2.Store()
 								return true
 							}
+							content, span := storeFlushSpan(l2, pos)
 							toks = append(toks, Token{
 								t:    TokenTypeStringContent,
-								text: l2.StoreFlush(),
+								text: content,
+								span: span,
 							})
 							l2.Mark()
-							l2.NextUntil("}")
+							if !l2.NextUntil("}") {
+								diags = append(diags, wirdiag.DiagnosticNew(l2.PositionAt(pos), "unterminated interpolation, expected '}'"))
+								return false
+							}
 							toks = append(toks, Token{
 								t:    TokenTypeDollarSignInterpolation,
 								text: l2.PullFromMark(),
+								span: spanOf(l2, l2.MarkedPos(), l2.Pos()+1),
 							})
 						}
 					case "'":
@@ -292,23 +528,27 @@ func phase2(l *runelexer.RuneLexer[Token]) error {
 								toks = append(toks, Token{
 									t:    TokenTypeStringStart,
 									text: "'",
+									span: spanOf(l2, pos, pos+1),
 								})
 								return true
 							}
 							if l2.AtEnd() && ch == "'" {
-								flush := l2.StoreFlush()
+								flush, span := storeFlushSpan(l2, pos)
 								if flush != "" {
 									toks = append(toks, Token{
 										t:    TokenTypeStringContent,
 										text: flush,
+										span: span,
 									})
 								}
 								toks = append(toks, Token{
 									t:    TokenTypeStringEnd,
 									text: "'",
+									span: spanOf(l2, pos, pos+1),
 								})
 								return true
 							}
+							l2.Store()
 						}
 					case "\"":
 						{
@@ -316,20 +556,25 @@ func phase2(l *runelexer.RuneLexer[Token]) error {
 								toks = append(toks, Token{
 									t:    TokenTypeStringStart,
 									text: "\"",
+									span: spanOf(l2, pos, pos+1),
 								})
 								return true
 							}
 							if l2.AtEnd() && ch == "\"" {
+								content, span := storeFlushSpan(l2, pos)
 								toks = append(toks, Token{
 									t:    TokenTypeStringContent,
-									text: l2.StoreFlush(),
+									text: content,
+									span: span,
 								})
 								toks = append(toks, Token{
 									t:    TokenTypeStringEnd,
 									text: "\"",
+									span: spanOf(l2, pos, pos+1),
 								})
 								return true
 							}
+							l2.Store()
 						}
 					}
 					return true
@@ -337,7 +582,7 @@ func phase2(l *runelexer.RuneLexer[Token]) error {
 			}
 		case TokenTypeAtDirective:
 			{
-				l2 := runelexer.NewRuneLexer[Token](tk.text)
+				l2 := runelexer.NewRuneLexerAt[Token](tk.text, tk.span.Start)
 				l2.Iter(func(ch string, pos int) bool {
 					switch ch {
 					default:
@@ -349,75 +594,134 @@ func phase2(l *runelexer.RuneLexer[Token]) error {
 							toks = append(toks, Token{
 								t:    TokenTypeAtDirectiveParenthesisClose,
 								text: ")",
+								span: spanOf(l2, pos, pos+1),
 							})
 						}
 					case "(":
 						{
-							directiveName := l2.StoreFlush()
+							directiveName, span := storeFlushSpan(l2, pos)
 							toks = append(toks, Token{
-								t:    TokenTypeAtDirectiveName,
+								t:    directiveNameType(directiveName),
 								text: directiveName,
+								span: span,
 							})
 							toks = append(toks, Token{
 								t:    TokenTypeAtDirectiveParenthesisOpen,
 								text: "(",
+								span: spanOf(l2, pos, pos+1),
 							})
 							l2.Next()
 							l2.Mark()
 							l2.GoToEnd()
 							l2.Prev()
-							l2.PullFromMark()
-							directiveInputParams := l2.PullFromMark()
-							directiveInputParts := strings.Split(directiveInputParams, ":")
-							for i, v := range directiveInputParts {
-								v = strings.TrimSpace(v)
-								if i%2 == 0 {
-									toks = append(toks, Token{
-										t:    TokenTypeAtDirectiveParamValue,
-										text: v,
-									})
-									toks = append(toks, Token{
-										t:    TokenTypeAtDirectiveSemiColon,
-										text: ":",
-									})
-								} else {
-									toks = append(toks, Token{
-										t:    TokenTypeAtDirectiveParamType,
-										text: v,
-									})
-								}
+							directiveInputParams := ""
+							if l2.Pos() >= l2.MarkedPos() {
+								directiveInputParams = l2.PullFromMark()
 							}
+							if directiveName == "props" {
+								toks = append(toks, splitProps(l2, directiveInputParams, l2.MarkedPos())...)
+								return true
+							}
+							if directiveName == "case" || directiveName == "import" || directiveName == "slot" || directiveName == "fill" {
+								text, span := trimSpan(l2, directiveInputParams, l2.MarkedPos())
+								toks = append(toks, Token{
+									t:    TokenTypeAtDirectiveParamValue,
+									text: text,
+									span: span,
+								})
+								return true
+							}
+							toks = append(toks, splitParams(l2, directiveInputParams, l2.MarkedPos(),
+								TokenTypeAtDirectiveParamValue,
+								TokenTypeAtDirectiveSemiColon,
+								TokenTypeAtDirectiveParamType,
+							)...)
 						}
 					case "@":
 						{
 							toks = append(toks, Token{
 								t:    TokenTypeAtDirectiveStart,
 								text: "@",
+								span: spanOf(l2, pos, pos+1),
 							})
 						}
 					}
 					return true
 				})
+				if l2.StoreLen() > 0 {
+					directiveName, span := storeFlushSpan(l2, l2.Len())
+					toks = append(toks, Token{
+						t:    directiveNameType(directiveName),
+						text: directiveName,
+						span: span,
+					})
+				}
 			}
 		}
 		return true
 	})
 	l.TokenOverwrite(toks)
-	return nil
+	return diags
 }
 
-func phase1(l *runelexer.RuneLexer[Token]) error {
+func phase1(l *runelexer.RuneLexer[Token]) wirdiag.List {
 	collectStore := func(l *runelexer.RuneLexer[Token]) {
-		flush := l.StoreFlush()
-		s := strings.TrimSpace(flush)
-		if s != "" {
-			l.TokenAppend(Token{
-				t:    TokenTypeRawText,
-				text: strings.TrimSpace(s),
+		if l.StoreLen() == 0 {
+			return
+		}
+		start := l.StoreStart()
+		runes := []rune(l.StoreFlush())
+		wordStart := -1
+		for i := 0; i <= len(runes); i++ {
+			if i < len(runes) && !unicode.IsSpace(runes[i]) {
+				if wordStart < 0 {
+					wordStart = i
+				}
+				continue
+			}
+			if wordStart >= 0 {
+				l.TokenAppend(Token{
+					t:    TokenTypeRawText,
+					text: string(runes[wordStart:i]),
+					span: spanOf(l, start+wordStart, start+i),
+				})
+				wordStart = -1
+			}
+		}
+	}
+	// resume steps back from the brace or newline a failed scan stopped on
+	// so lexing carries on from it and later problems are still reported.
+	resume := func(l *runelexer.RuneLexer[Token]) {
+		ch := l.Char()
+		if ch == "{" || ch == "}" || ch == "\n" {
+			l.Prev()
+		}
+	}
+	collectString := func(l *runelexer.RuneLexer[Token], quote string) *wirdiag.Diagnostic {
+		collectStore(l)
+		l.Mark()
+		found := false
+		if !l.AtEnd() {
+			l.Next()
+			l.Iter(func(ch2 string, pos int) bool {
+				if ch2 == quote && l.Peek(-1) != "\\" {
+					l.TokenAppend(Token{
+						t:    TokenTypeString,
+						text: l.PullFromMark(),
+						span: spanOf(l, l.MarkedPos(), pos+1),
+					})
+					found = true
+					return false
+				}
+				return true
 			})
 		}
+		if !found {
+			return wirdiag.DiagnosticNew(l.PositionAt(l.MarkedPos()), "unterminated string, expected a closing %s", quote)
+		}
+		return nil
 	}
-	ranFinal := false
+	var diags wirdiag.List
 	for {
 		ch := l.Char()
 		switch ch {
@@ -433,49 +737,50 @@ func phase1(l *runelexer.RuneLexer[Token]) error {
 					break
 				}
 				collectStore(l)
-				if l.Pull(4) == "@for(" {
-					l.Mark()
-					l.NextUntil(")")
+				name := directiveAt(l)
+				params, known := directives[name]
+				hasParens := l.Peek(len([]rune(name))+1) == "("
+				if !known || (params == paramsRequired && !hasParens) {
+					l.Store()
+					break
+				}
+				l.Mark()
+				if params == paramsNone || !hasParens {
+					l.NextBy(len([]rune(name)))
 					l.TokenAppend(Token{
 						t:    TokenTypeAtDirective,
 						text: l.PullFromMark(),
+						span: spanOf(l, l.MarkedPos(), l.Pos()+1),
 					})
-				} else {
-					l.Store()
 					break
 				}
-			}
-		case "'":
-			{
-				collectStore(l)
-				l.Mark()
-				l.Next()
+				found := false
 				l.Iter(func(ch2 string, pos int) bool {
-					if ch2 == "'" && l.Peek(-1) != "\\" {
-						l.TokenAppend(Token{
-							t:    TokenTypeString,
-							text: l.PullFromMark(),
-						})
-						return false
+					if l.InQuote() {
+						return true
 					}
-					return true
+					if ch2 == ")" {
+						found = true
+					}
+					return !found && ch2 != "{" && ch2 != "}" && (ch2 != "\n" || name == "props")
+				})
+				if !found {
+					diags = append(diags, wirdiag.DiagnosticNew(l.PositionAt(l.MarkedPos()), "unterminated @%s directive, expected ')'", name))
+					resume(l)
+					break
+				}
+				l.TokenAppend(Token{
+					t:    TokenTypeAtDirective,
+					text: l.PullFromMark(),
+					span: spanOf(l, l.MarkedPos(), l.Pos()+1),
 				})
 			}
-		case "\"":
+		case "'", "\"":
 			{
-				collectStore(l)
-				l.Mark()
-				l.Next()
-				l.Iter(func(ch2 string, pos int) bool {
-					if ch2 == "\"" && l.Peek(-1) != "\\" {
-						l.TokenAppend(Token{
-							t:    TokenTypeString,
-							text: l.PullFromMark(),
-						})
-						return false
-					}
-					return true
-				})
+				diag := collectString(l, ch)
+				if diag != nil {
+					diags = append(diags, diag)
+				}
 			}
 		case "{":
 			{
@@ -487,6 +792,7 @@ func phase1(l *runelexer.RuneLexer[Token]) error {
 				l.TokenAppend(Token{
 					t:    TokenTypeCurlyBraceOpen,
 					text: "{",
+					span: spanOf(l, l.Pos(), l.Pos()+1),
 				})
 			}
 		case "}":
@@ -499,6 +805,7 @@ func phase1(l *runelexer.RuneLexer[Token]) error {
 				l.TokenAppend(Token{
 					t:    TokenTypeCurlyBraceClose,
 					text: "}",
+					span: spanOf(l, l.Pos(), l.Pos()+1),
 				})
 			}
 		case "<":
@@ -508,48 +815,41 @@ func phase1(l *runelexer.RuneLexer[Token]) error {
 					break
 				}
 				collectStore(l)
-				if l.StoreLen() > 0 {
-					l.TokenAppend(Token{
-						t:    TokenTypeRawText,
-						text: l.StoreFlush(),
-					})
-				}
 				l.Mark()
+				found := false
 				l.Iter(func(ch2 string, pos int) bool {
 					if l.InQuote() {
 						return true
 					}
-					if ch2 != ">" && ch2 != "}" {
+					if ch2 != ">" && ch2 != "{" && ch2 != "}" {
 						return true
 					}
-					if ch2 == "}" {
-						l.TokenAppend(Token{
-							t:    TokenTypeRawText,
-							text: l.PullFromMark(),
-						})
-					}
 					if ch2 == ">" {
 						l.TokenAppend(Token{
 							t:    TokenTypeTagInfo,
 							text: l.PullFromMark(),
+							span: spanOf(l, l.MarkedPos(), pos+1),
 						})
+						found = true
 					}
 					return false
 				})
+				if !found {
+					diags = append(diags, wirdiag.DiagnosticNew(l.PositionAt(l.MarkedPos()), "unclosed attribute list, expected '>'"))
+					resume(l)
+				}
 			}
 		}
-		l.Next()
 		if l.AtEnd() {
-			if ranFinal {
-				break
-			} else {
-				ranFinal = true
-			}
+			break
 		}
+		l.Next()
 	}
+	collectStore(l)
 	l.TokenAppend(Token{
 		t:    TokenTypeEndOfFile,
 		text: "EOF",
+		span: spanOf(l, l.Len(), l.Len()),
 	})
-	return nil
-}
\ No newline at end of file
+	return diags
+}
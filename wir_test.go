@@ -1,13 +1,19 @@
 package webir_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/phillip-england/wir/examples/go/userlist"
 	"github.com/phillip-england/wir/internal/soak"
 	"github.com/phillip-england/wir/internal/wherr"
+	"github.com/phillip-england/wir/internal/wirdiag"
+	"github.com/phillip-england/wir/internal/wirgen"
 	"github.com/phillip-england/wir/internal/wirparser"
 	"github.com/phillip-england/wir/internal/wirtokenizer"
 )
@@ -66,3 +72,649 @@ func TestExamplesTokenized(t *testing.T) {
 		fail(t, wherr.Consume(wherr.Here(), err, ""))
 	}
 }
+
+func TestWirParserAst(t *testing.T) {
+	cwd, _ := os.Getwd()
+	tk, err := wirtokenizer.TokenizerNewFromFile(path.Join(cwd, "examples", "raw", "user_list.wir"))
+	if err != nil {
+		fail(t, wherr.Consume(wherr.Here(), err, ""))
+		return
+	}
+	p, err := wirparser.ParserNew(tk.Lexer.Tokens())
+	if err != nil {
+		fail(t, wherr.Consume(wherr.Here(), err, ""))
+		return
+	}
+	root := p.Ast().Root
+	if len(root.Children) != 1 {
+		fail(t, wherr.Err(wherr.Here(), "expected 1 root child but found %d", len(root.Children)))
+		return
+	}
+	ul := root.Children[0]
+	if ul.Kind != wirparser.AstNodeKindElement || ul.TagName != "ul" {
+		fail(t, wherr.Err(wherr.Here(), "expected ul element but found %s %s", ul.Kind, ul.TagName))
+		return
+	}
+	if len(ul.Attrs) != 2 || ul.Attrs[0].Key != "id" || ul.Attrs[0].Parts[0].Text != "my-list" {
+		fail(t, wherr.Err(wherr.Here(), "ul attributes were not parsed correctly"))
+		return
+	}
+	class := ul.Attrs[1]
+	if len(class.Parts) != 2 || class.Parts[0].Text != "bg-black " || class.Parts[1].Value != "someClass" || class.Parts[1].ValueType != "string" {
+		fail(t, wherr.Err(wherr.Here(), "partial attribute value was not reassembled"))
+		return
+	}
+	kinds := []wirparser.AstNodeKind{wirparser.AstNodeKindElement, wirparser.AstNodeKindString, wirparser.AstNodeKindForDirective}
+	if len(ul.Children) != len(kinds) {
+		fail(t, wherr.Err(wherr.Here(), "expected %d ul children but found %d", len(kinds), len(ul.Children)))
+		return
+	}
+	for i, kind := range kinds {
+		if ul.Children[i].Kind != kind {
+			fail(t, wherr.Err(wherr.Here(), "expected child %d to be %s but found %s", i, kind, ul.Children[i].Kind))
+		}
+	}
+	loop := ul.Children[2]
+	if loop.Binding != "user" || loop.BindingType != "User" || len(loop.Children) != 1 {
+		fail(t, wherr.Err(wherr.Here(), "@for directive was not parsed correctly"))
+		return
+	}
+	str := loop.Children[0].Children[0]
+	if str.Kind != wirparser.AstNodeKindString || len(str.Children) != 2 || str.Children[1].Value != "user.name" {
+		fail(t, wherr.Err(wherr.Here(), "string interpolation inside @for was not parsed correctly"))
+	}
+}
+
+func TestWirParserUnbalanced(t *testing.T) {
+	for _, src := range []string{"div {", "div } }", "@for(user: User) { li"} {
+		tk, err := wirtokenizer.TokenizerNewFromString(src)
+		if err != nil {
+			fail(t, wherr.Consume(wherr.Here(), err, ""))
+			continue
+		}
+		_, err = wirparser.ParserNew(tk.Lexer.Tokens())
+		if err == nil {
+			fail(t, wherr.Err(wherr.Here(), "expected an error parsing %q", src))
+		}
+	}
+}
+
+func TestTokenSpans(t *testing.T) {
+	cwd, _ := os.Getwd()
+	fBytes, err := os.ReadFile(path.Join(cwd, "examples", "raw", "user_list.wir"))
+	if err != nil {
+		fail(t, wherr.Consume(wherr.Here(), err, ""))
+		return
+	}
+	src := "\n\n" + string(fBytes)
+	tk, err := wirtokenizer.TokenizerNewFromString(src)
+	if err != nil {
+		fail(t, wherr.Consume(wherr.Here(), err, ""))
+		return
+	}
+	for _, tok := range tk.Lexer.Tokens() {
+		span := tok.Span()
+		if tok.Type() == wirtokenizer.TokenTypeEndOfFile || span.Start.Offset == span.End.Offset {
+			continue
+		}
+		if got := src[span.Start.Offset:span.End.Offset]; got != tok.Text() {
+			fail(t, wherr.Err(wherr.Here(), "token %s spans %q in source", tok.Str(), got))
+		}
+		lines := strings.Split(src, "\n")
+		rest := string([]rune(lines[span.Start.Line-1])[span.Start.Column-1:])
+		if !strings.HasPrefix(rest+"\n"+strings.Join(lines[span.Start.Line:], "\n"), tok.Text()) {
+			fail(t, wherr.Err(wherr.Here(), "token %s is not at %s", tok.Str(), span.Start.Str()))
+		}
+	}
+	p, err := wirparser.ParserNew(tk.Lexer.Tokens())
+	if err != nil {
+		fail(t, wherr.Consume(wherr.Here(), err, ""))
+		return
+	}
+	loop := p.Ast().Root.Children[0].Children[2]
+	if loop.Span.Start.Str() != "6:3" || loop.Span.End.Str() != "8:4" {
+		fail(t, wherr.Err(wherr.Here(), "@for directive has span %s", loop.Span.Str()))
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	cases := []struct {
+		src      string
+		location string
+		message  string
+	}{
+		{"ul {\n  'abc\n}", "bad.wir:2:3", "unterminated string"},
+		{"ul<id='x' {\n  li\n}", "bad.wir:1:3", "expected '>'"},
+		{"div {\n  li\n", "bad.wir:1:5", "unclosed '{'"},
+		{"div {\n  li\n}\n}", "bad.wir:4:1", "no matching '{'"},
+		{"@for(user: User {\n  li\n}", "bad.wir:1:1", "expected ')'"},
+		{"ul { 'a ${x' }", "bad.wir:1:9", "unterminated interpolation"},
+	}
+	for _, c := range cases {
+		tk, err := wirtokenizer.TokenizerNewFromSource("bad.wir", c.src)
+		if err == nil {
+			_, err = wirparser.ParserNewFromTokenizer(tk)
+		}
+		diags, ok := err.(wirdiag.List)
+		if !ok || len(diags) != 1 {
+			fail(t, wherr.Err(wherr.Here(), "expected one diagnostic for %q but got %v", c.src, err))
+			continue
+		}
+		diag := diags[0]
+		if diag.Location() != c.location || !strings.Contains(diag.Message, c.message) {
+			fail(t, wherr.Err(wherr.Here(), "unexpected diagnostic for %q:\n%s", c.src, diag.Error()))
+		}
+		if !strings.HasSuffix(diag.Excerpt, "^") {
+			fail(t, wherr.Err(wherr.Here(), "diagnostic for %q has no caret excerpt:\n%s", c.src, diag.Error()))
+		}
+	}
+}
+
+func TestParserRecovery(t *testing.T) {
+	src := `div {
+  ul<id=> {
+    li { 'a' }
+  }
+  '${}'
+  p { 'ok' }
+  @for(user) { li }
+}
+}
+span`
+	tk, err := wirtokenizer.TokenizerNewFromSource("bad.wir", src)
+	if err != nil {
+		fail(t, wherr.Consume(wherr.Here(), err, ""))
+		return
+	}
+	p, err := wirparser.ParserNewFromTokenizer(tk)
+	diags, ok := err.(wirdiag.List)
+	if !ok {
+		fail(t, wherr.Err(wherr.Here(), "expected a diagnostic list but got %v", err))
+		return
+	}
+	locations := []string{"bad.wir:2:6", "bad.wir:5:4", "bad.wir:7:8", "bad.wir:9:1"}
+	if len(diags) != len(locations) {
+		fail(t, wherr.Err(wherr.Here(), "expected %d diagnostics but found %d:\n%s", len(locations), len(diags), diags.Error()))
+		return
+	}
+	for i, location := range locations {
+		if diags[i].Location() != location {
+			fail(t, wherr.Err(wherr.Here(), "expected diagnostic at %s but found:\n%s", location, diags[i].Error()))
+		}
+	}
+	root := p.Ast().Root
+	if len(root.Children) != 2 || root.Children[1].TagName != "span" {
+		fail(t, wherr.Err(wherr.Here(), "parsing did not resume after errors"))
+	}
+}
+
+func TestTokenizerRecovery(t *testing.T) {
+	src := "ul<id='x' {\n  @for(user: User {\n  }\n  'a ${x'\n}"
+	_, err := wirtokenizer.TokenizerNewFromSource("bad.wir", src)
+	diags, ok := err.(wirdiag.List)
+	if !ok || len(diags) != 3 {
+		fail(t, wherr.Err(wherr.Here(), "expected 3 diagnostics but got %v", err))
+	}
+}
+
+func TestExamplesParsed(t *testing.T) {
+	d, err := soak.NewMirror([]string{"examples", "raw"}, []string{"examples", "ast"})
+	if err != nil {
+		fail(t, wherr.Consume(wherr.Here(), err, ""))
+		return
+	}
+	d.Iter(func(target *soak.VirtualAsset, compare *soak.VirtualAsset) bool {
+		tk, err := wirtokenizer.TokenizerNewFromSource(target.RelPath, target.Text)
+		if err != nil {
+			fail(t, wherr.Consume(wherr.Here(), err, ""))
+			return true
+		}
+		p, err := wirparser.ParserNewFromTokenizer(tk)
+		if err != nil {
+			fail(t, wherr.Consume(wherr.Here(), err, ""))
+			return true
+		}
+		out, err := p.Ast().Json()
+		if err != nil {
+			fail(t, wherr.Consume(wherr.Here(), err, ""))
+			return true
+		}
+		if out != compare.Text {
+			fail(t, wherr.Err(wherr.Here(), "ast of [%s] does not match [%s]", target.Path, compare.Path))
+		}
+		return true
+	})
+}
+
+func loadExampleProps(t *testing.T) map[string]any {
+	fBytes, err := os.ReadFile(path.Join("examples", "props.json"))
+	if err != nil {
+		fail(t, wherr.Consume(wherr.Here(), err, ""))
+		return nil
+	}
+	props := map[string]any{}
+	err = json.Unmarshal(fBytes, &props)
+	if err != nil {
+		fail(t, wherr.Consume(wherr.Here(), err, ""))
+		return nil
+	}
+	return props
+}
+
+// buildExamples generates every example component with the named target and
+// compares the output to the goldens in examples/<dir>. unsupported lists
+// examples the target must report errors for, which have no golden.
+func buildExamples(t *testing.T, name string, dir string, opts wirgen.TargetOptions, unsupported ...string) {
+	target, err := wirgen.TargetNew(name, opts)
+	if err != nil {
+		fail(t, wherr.Consume(wherr.Here(), err, ""))
+		return
+	}
+	raw, err := soak.LoadVfs(true, "examples", "raw")
+	if err != nil {
+		fail(t, wherr.Consume(wherr.Here(), err, ""))
+		return
+	}
+	project := wirgen.ProjectNewFromVfs(raw)
+	raw.IterAssets(func(asset *soak.VirtualAsset) bool {
+		c, err := project.Component(asset.RelPath)
+		if err != nil {
+			fail(t, wherr.Consume(wherr.Here(), err, ""))
+			return true
+		}
+		out, err := target.Generate(c)
+		if slices.Contains(unsupported, asset.FileName) {
+			if err == nil {
+				fail(t, wherr.Err(wherr.Here(), "expected %s to report errors for [%s]", name, asset.Path))
+			}
+			return true
+		}
+		if err != nil {
+			fail(t, wherr.Consume(wherr.Here(), err, ""))
+			return true
+		}
+		// goldens are looked up by the target's extension rather than mirrored
+		// by name, since extensions such as .html.j2 have more than one dot
+		expectedDir := path.Join("examples", dir)
+		if nested, ok := target.(wirgen.TargetNested); ok {
+			expectedDir = path.Join(expectedDir, nested.Dir(c))
+		}
+		expectedPath := path.Join(expectedDir, asset.FileNameNoExt+target.Ext())
+		expected, err := os.ReadFile(expectedPath)
+		if err != nil {
+			fail(t, wherr.Consume(wherr.Here(), err, ""))
+			return true
+		}
+		if out != string(expected) {
+			fail(t, wherr.Err(wherr.Here(), "%s output of [%s] does not match [%s]", name, asset.Path, expectedPath))
+		}
+		return true
+	})
+}
+
+// generate builds src, named file, with the named target.
+func generate(name string, opts wirgen.TargetOptions, file string, src string) (string, error) {
+	target, err := wirgen.TargetNew(name, opts)
+	if err != nil {
+		return "", err
+	}
+	c, err := wirgen.ComponentNewFromSource(file, src)
+	if err != nil {
+		return "", err
+	}
+	return target.Generate(c)
+}
+
+// expectDiagnostics checks that err holds exactly the diagnostics in want,
+// each written as file.wir:line:column: message.
+func expectDiagnostics(t *testing.T, err error, want ...string) {
+	diags, ok := err.(wirdiag.List)
+	if !ok || len(diags) != len(want) {
+		fail(t, wherr.Err(wherr.Here(), "expected %d diagnostics but got %v", len(want), err))
+		return
+	}
+	for i, d := range diags {
+		got := d.Location() + ": " + d.Message
+		if got != want[i] {
+			fail(t, wherr.Err(wherr.Here(), "expected [%s] but got [%s]", want[i], got))
+		}
+	}
+}
+
+func TestComponentProps(t *testing.T) {
+	src := `ul<class='${someClass}'> {
+  @for(user: User) {
+    li { '${user.name: string} is ${user.age: int}' }
+  }
+}`
+	c, err := wirgen.ComponentNewFromSource("user_list.wir", src)
+	if err != nil {
+		fail(t, wherr.Consume(wherr.Here(), err, ""))
+		return
+	}
+	if c.Name != "UserList" || len(c.Props) != 2 || c.Props[0].Type != "string" || c.Props[1].Name != "users" || c.Props[1].Type != "[]User" {
+		fail(t, wherr.Err(wherr.Here(), "unexpected props %+v on %s", c.Props, c.Name))
+	}
+	user, exists := c.Type("User")
+	if !exists || len(user.Fields) != 2 || user.Fields[1].Name != "age" || user.Fields[1].Type != "int" {
+		fail(t, wherr.Err(wherr.Here(), "unexpected types %+v", c.Types))
+	}
+	_, err = wirgen.ComponentNewFromSource("bad.wir", "ul { '${a: int} ${a: string} ${b.c}' }")
+	diags, ok := err.(wirdiag.List)
+	if !ok || len(diags) != 2 {
+		fail(t, wherr.Err(wherr.Here(), "expected two diagnostics but got %v", err))
+	}
+}
+
+func TestExamplesBuiltHtml(t *testing.T) {
+	buildExamples(t, "html", "html", wirgen.TargetOptions{Props: loadExampleProps(t)})
+}
+
+func TestHtmlMissingProps(t *testing.T) {
+	opts := wirgen.TargetOptions{Props: map[string]any{"users": []any{map[string]any{"name": 1.0}}}}
+	_, err := generate("html", opts, "list.wir", "ul {\n  '${title}'\n  @for(user: User) { li { '${user.name}' } }\n}")
+	expectDiagnostics(t, err,
+		"list.wir:2:4: no value for title, add it to the props file",
+		"list.wir:3:28: user.name should be a string but the props file has an int",
+	)
+}
+
+func TestExamplesBuiltGo(t *testing.T) {
+	buildExamples(t, "go", "go", wirgen.TargetOptions{Module: "github.com/phillip-england/wir/examples/go"})
+}
+
+func TestGoRender(t *testing.T) {
+	var sb strings.Builder
+	err := userlist.Render(&sb, userlist.Props{
+		SomeClass: "text-white",
+		ListName:  "Team",
+		Users:     []userlist.User{{Name: "Ada"}, {Name: "Linus <3"}},
+	})
+	if err != nil {
+		fail(t, wherr.Consume(wherr.Here(), err, ""))
+		return
+	}
+	expected := `<ul id="my-list" class="bg-black text-white"><li></li>Name: Team<li>name: Ada</li><li>name: Linus &lt;3</li></ul>`
+	if sb.String() != expected {
+		fail(t, wherr.Err(wherr.Here(), "unexpected render output %s", sb.String()))
+	}
+}
+
+func TestExamplesBuiltReact(t *testing.T) {
+	buildExamples(t, "react", "react", wirgen.TargetOptions{})
+}
+
+// TestJsxRootFragment checks that roots that aren't a single tag are
+// wrapped in a fragment, since a bare {expression} isn't valid JSX.
+func TestJsxRootFragment(t *testing.T) {
+	cases := map[string][]string{
+		"'Hi ${name}'":                 {"react", "solid"},
+		"@slot":                        {"react", "solid"},
+		"@for(item: string) { li }":    {"react"},
+		"@if(ok) { p } @else { span }": {"react"},
+	}
+	for src, targets := range cases {
+		for _, name := range targets {
+			out, err := generate(name, wirgen.TargetOptions{}, "root.wir", src)
+			if err != nil {
+				fail(t, wherr.Consume(wherr.Here(), err, ""))
+				continue
+			}
+			if !strings.Contains(out, "return (\n    <>\n") {
+				fail(t, wherr.Err(wherr.Here(), "%s root of [%s] is not wrapped in a fragment:\n%s", name, src, out))
+			}
+		}
+	}
+}
+
+func TestExamplesBuiltVue(t *testing.T) {
+	buildExamples(t, "vue", "vue", wirgen.TargetOptions{})
+}
+
+func TestVueBraceText(t *testing.T) {
+	out, err := generate("vue", wirgen.TargetOptions{}, "braces.wir", "p { '{{y}} ${x}' }")
+	if err != nil {
+		fail(t, wherr.Consume(wherr.Here(), err, ""))
+		return
+	}
+	if !strings.Contains(out, "<p>&#123;&#123;y}} {{ x }}</p>") {
+		fail(t, wherr.Err(wherr.Here(), "static braces were not escaped:\n%s", out))
+	}
+}
+
+func TestExamplesBuiltSvelte(t *testing.T) {
+	buildExamples(t, "svelte", "svelte", wirgen.TargetOptions{})
+}
+
+func TestExamplesBuiltSolid(t *testing.T) {
+	buildExamples(t, "solid", "solid", wirgen.TargetOptions{})
+}
+
+func TestExamplesBuiltWebComponent(t *testing.T) {
+	buildExamples(t, "webcomponent", "webcomponent", wirgen.TargetOptions{})
+}
+
+func TestWebComponentRootRedraw(t *testing.T) {
+	out, err := generate("webcomponent", wirgen.TargetOptions{}, "greet.wir", "@if(ok) { p { 'Hi ${name}' } }")
+	if err != nil {
+		fail(t, wherr.Consume(wherr.Here(), err, ""))
+		return
+	}
+	if strings.Contains(out, "#update") || strings.Contains(out, "data-wir") {
+		fail(t, wherr.Err(wherr.Here(), "a root @if should only be redrawn by render():\n%s", out))
+	}
+}
+
+func TestExamplesBuiltLit(t *testing.T) {
+	buildExamples(t, "lit", "lit", wirgen.TargetOptions{})
+}
+
+func TestExamplesBuiltSwiftUI(t *testing.T) {
+	buildExamples(t, "swiftui", "swiftui", wirgen.TargetOptions{})
+}
+
+func TestSwiftUIUnmappedElement(t *testing.T) {
+	_, err := generate("swiftui", wirgen.TargetOptions{}, "card.wir", "div {\n  span { 'x' }\n}")
+	expectDiagnostics(t, err, "card.wir:2:3: <span> has no SwiftUI mapping, expected one of h1, p, button, ul, li or div")
+}
+
+func TestExamplesBuiltCompose(t *testing.T) {
+	buildExamples(t, "compose", "compose", wirgen.TargetOptions{})
+}
+
+func TestComposeDiagnostics(t *testing.T) {
+	_, err := generate("compose", wirgen.TargetOptions{}, "card.wir", "div<style='x'> {\n  span { 'x' }\n}")
+	expectDiagnostics(t, err,
+		"card.wir:1:5: attribute style on <div> has no Compose mapping, only id and class are supported",
+		"card.wir:2:3: <span> has no Compose mapping, expected one of h1, p, button, ul, li or div",
+	)
+}
+
+func TestExamplesBuiltFlutter(t *testing.T) {
+	buildExamples(t, "flutter", "flutter", wirgen.TargetOptions{})
+}
+
+func TestExamplesBuiltTempl(t *testing.T) {
+	buildExamples(t, "templ", "templ", wirgen.TargetOptions{Module: "github.com/phillip-england/wir/examples/templ"})
+}
+
+func TestExamplesBuiltJinja(t *testing.T) {
+	buildExamples(t, "jinja", "jinja", wirgen.TargetOptions{})
+}
+
+func TestExamplesBuiltHandlebars(t *testing.T) {
+	buildExamples(t, "handlebars", "handlebars", wirgen.TargetOptions{})
+}
+
+func TestExamplesBuiltMustache(t *testing.T) {
+	buildExamples(t, "mustache", "mustache", wirgen.TargetOptions{}, "panel.wir", "settings_panel.wir", "status_badge.wir", "toolbar.wir")
+}
+
+func TestHandlebarsPartialAttr(t *testing.T) {
+	src := "@for(user: User) {\n  a<href='/users/${user.id: int}?tab=${tab: string}'>\n}"
+	out, err := generate("handlebars", wirgen.TargetOptions{}, "links.wir", src)
+	if err != nil {
+		fail(t, wherr.Consume(wherr.Here(), err, ""))
+		return
+	}
+	if !strings.Contains(out, `<a href="/users/{{id}}?tab={{../tab}}"></a>`) {
+		fail(t, wherr.Err(wherr.Here(), "attribute was not reassembled:\n%s", out))
+	}
+}
+
+func TestMustacheShadowedField(t *testing.T) {
+	src := "@for(user: User) {\n  p { '${user.name: string} ${name: string}' }\n}"
+	_, err := generate("mustache", wirgen.TargetOptions{}, "names.wir", src)
+	expectDiagnostics(t, err, "names.wir:2:29: mustache would read name from user.name, rename the field or use the handlebars target")
+}
+
+func TestExamplesBuiltBlade(t *testing.T) {
+	buildExamples(t, "blade", "blade", wirgen.TargetOptions{})
+}
+
+func TestIfDirectiveDiagnostics(t *testing.T) {
+	cases := map[string]string{
+		"p\n@else { p }":                          "stray.wir:2:1: @else without a matching @if",
+		"@if(count: int) { p }":                   "count.wir:1:1: condition count must be a bool but is declared as int",
+		"@if(ok) { p } @elseif(ok: string) { p }": "mixed.wir:1:15: condition ok must be a bool but is declared as string",
+	}
+	for src, want := range cases {
+		name := strings.SplitN(want, ":", 2)[0]
+		_, err := wirgen.ComponentNewFromSource(name, src)
+		expectDiagnostics(t, err, want)
+	}
+}
+
+func TestSwitchDirectiveDiagnostics(t *testing.T) {
+	cases := map[string]string{
+		"@switch(s) {\n  @case('a') { p }\n  @case('a') { p }\n}":                 "dup.wir:3:3: duplicate @case('a'), already handled at 2:3",
+		"@switch(s) {\n  @default { p }\n  @case('a') { p }\n  @default { p }\n}": "defaults.wir:4:3: @switch already has a @default at 2:3",
+		"@switch(s) { @case(a) { p } }":                                           "unquoted.wir:1:20: case value a must be quoted, such as @case('a')",
+		"@case('a') { p }":                                                        "stray.wir:1:1: @case outside of a @switch",
+		"@switch(count: int) { @case('1') { p } }":                                "count.wir:1:1: @switch value count must be a string or an enum type such as Status, not int",
+		"@switch(s: Status) { @case('a') { p } }\np { '${s: Status}' }":           "enum.wir:2:6: cannot interpolate s of enum type Status, use @switch to render each value",
+	}
+	for src, want := range cases {
+		name := strings.SplitN(want, ":", 2)[0]
+		_, err := wirgen.ComponentNewFromSource(name, src)
+		expectDiagnostics(t, err, want)
+	}
+}
+
+func TestMustacheSwitch(t *testing.T) {
+	_, err := generate("mustache", wirgen.TargetOptions{}, "status.wir", "@switch(status) { @case('a') { p } }")
+	expectDiagnostics(t, err, "status.wir:1:1: mustache can't compare values, use the handlebars target for @switch")
+}
+
+func TestPropsDeclaration(t *testing.T) {
+	src := "@props(\n  title: string,\n  count: int = 2,\n  status: Status = 'on',\n)\n@switch(status) { @case('on') { p { '${title} ${count}' } } }"
+	c, err := wirgen.ComponentNewFromSource("card.wir", src)
+	if err != nil {
+		fail(t, wherr.Consume(wherr.Here(), err, ""))
+		return
+	}
+	if len(c.Props) != 3 || c.Props[0].Default != nil || c.Props[1].Type != "int" || c.Props[1].Default != 2.0 || c.Props[2].Default != "on" {
+		fail(t, wherr.Err(wherr.Here(), "unexpected props %+v", c.Props))
+	}
+	if !c.IsEnum("Status") {
+		fail(t, wherr.Err(wherr.Here(), "expected Status to be an enum but got %+v", c.Types))
+	}
+}
+
+func TestPropsDirectiveDiagnostics(t *testing.T) {
+	cases := map[string]string{
+		"p\n@props(a: string)":                 "late.wir:2:1: @props must come first in the file, before any markup",
+		"@props(a: string)\n@props(b: string)": "twice.wir:2:1: @props is already declared at 1:1",
+		"@props(a: string, a: int)":            "dup.wir:1:19: duplicate prop a, already declared at 1:8",
+		"@props(a)":                            "untyped.wir:1:8: missing type for prop a, expected a: Type",
+		"@props()\np { '${x}' }":               "empty.wir:2:6: x is not a declared prop, add x: string to @props",
+		"@props(a: string)\np { '${b}' }":      "undeclared.wir:2:6: b is not a declared prop, add b: string to @props",
+		"@props(n: int)\np { '${n: string}' }": "mismatch.wir:2:6: n is declared as int at 1:8 but used as string here",
+		"@props(n: int = 'x')":                 "default.wir:1:8: invalid default 'x' for n, expected a whole number such as 0",
+		"@props(on: string)\n@if(on) { p }":    "cond.wir:2:1: condition on must be a bool but is declared as string",
+		"@props(u: User = [])":                 "user.wir:1:8: u of type User can't have a default, only primitives, lists and enums can",
+	}
+	for src, want := range cases {
+		name := strings.SplitN(want, ":", 2)[0]
+		_, err := wirgen.ComponentNewFromSource(name, src)
+		expectDiagnostics(t, err, want)
+	}
+}
+
+func TestImportDiagnostics(t *testing.T) {
+	button := "@props(label: string, size: int = 1)\nbutton { '${label}' }"
+	cases := map[string]string{
+		"@import('missing.wir')\np":                                   "page.wir:1:1: cannot find missing.wir",
+		"@import('page.wir')\np":                                      "page.wir:1:1: import cycle page.wir -> page.wir",
+		"p\n@import('button.wir')":                                    "page.wir:2:1: @import must come first in the file, before any markup",
+		"@import(button.wir)\np":                                      "page.wir:1:9: import path button.wir must be quoted, such as @import('button.wir')",
+		"Card<title='a'>":                                             "page.wir:1:1: unknown component Card, import it with @import('card.wir')",
+		"@import('button.wir')\nButton":                               "page.wir:2:1: Button is missing prop label: string",
+		"@import('button.wir')\nButton<label='a' color='red'>":        "page.wir:2:18: Button has no prop color",
+		"@import('button.wir')\nButton<label='a' size='big'>":         "page.wir:2:18: invalid value 'big' for size of Button, expected int",
+		"@import('button.wir')\n@props(n: int)\nButton<label='${n}'>": "page.wir:3:15: n is passed as int but label of Button has type string",
+	}
+	for src, want := range cases {
+		project := wirgen.ProjectNew()
+		project.Add("button.wir", button)
+		project.Add("page.wir", src)
+		_, err := project.Component("page.wir")
+		expectDiagnostics(t, err, want)
+	}
+}
+
+func TestImportFromSubdirectory(t *testing.T) {
+	project := wirgen.ProjectNew()
+	project.Add("cards/card.wir", "p { 'card' }")
+	project.Add("pages/home.wir", "@import('../cards/card.wir')\nCard")
+	c, err := project.Component("pages/home.wir")
+	if err != nil {
+		fail(t, wherr.Consume(wherr.Here(), err, ""))
+		return
+	}
+	cases := map[string]string{
+		"jinja":      `{% include "cards/card.html.j2" %}`,
+		"handlebars": `{{> cards/card}}`,
+		"blade":      `<x-cards.card />`,
+		"flutter":    `import '../cards/card.dart';`,
+	}
+	for name, want := range cases {
+		target, err := wirgen.TargetNew(name, wirgen.TargetOptions{})
+		if err != nil {
+			fail(t, wherr.Consume(wherr.Here(), err, ""))
+			continue
+		}
+		out, err := target.Generate(c)
+		if err != nil {
+			fail(t, wherr.Consume(wherr.Here(), err, ""))
+			continue
+		}
+		if !strings.Contains(out, want) {
+			fail(t, wherr.Err(wherr.Here(), "expected %s output to contain %s:\n%s", name, want, out))
+		}
+	}
+}
+
+func TestSlotDiagnostics(t *testing.T) {
+	card := "@props(title: string)\ndiv { @slot @slot('footer') }"
+	cases := map[string]string{
+		"@slot\n@slot":                            "page.wir:2:1: duplicate @slot, already declared at 1:1",
+		"@slot('a')\np { @slot('a') }":            "page.wir:2:5: duplicate @slot('a'), already declared at 1:1",
+		"@slot('a b')":                            "page.wir:1:1: invalid slot name a b, expected a name such as footer",
+		"@props(footer: string)\n@slot('footer')": "page.wir:2:1: @slot('footer') has the same name as the prop at 1:8",
+		"@fill('footer') { p }":                   "page.wir:1:1: @fill('footer') outside of a component",
+		"@import('card.wir')\nCard<title='a'> { @fill('x') { p } }":                            "page.wir:2:19: Card has no slot x",
+		"@import('card.wir')\nCard<title='a'> { @fill('footer') { p } @fill('footer') { p } }": "page.wir:2:41: duplicate @fill('footer'), already given at 2:19",
+		"@props(children: string)\n@slot":                                                      "page.wir:1:8: prop children has a name some targets give the @slot at 2:1, rename it",
+		"@slot('content')\n@slot":                                                              "page.wir:1:1: @slot('content') has a name some targets give the @slot at 2:1, rename it",
+		"@import('badge.wir')\nBadge { p span }":                                               "page.wir:2:9: Badge has no @slot and can't be given children",
+	}
+	for src, want := range cases {
+		project := wirgen.ProjectNew()
+		project.Add("card.wir", card)
+		project.Add("badge.wir", "span")
+		project.Add("page.wir", src)
+		_, err := project.Component("page.wir")
+		expectDiagnostics(t, err, want)
+	}
+}